@@ -51,3 +51,76 @@ func (rc *AlphaController) ValidationServer(w http.ResponseWriter, r *http.Reque
 func (rc *AlphaController) ValidationCli() error {
 	return rc.executor.ValidationCli()
 }
+
+func (rc *AlphaController) FormatServer(w http.ResponseWriter, r *http.Request) {
+	result, err := rc.executor.Format(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(result); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (rc *AlphaController) FormatCli(path string) error {
+	return rc.executor.FormatCli(path)
+}
+
+func (rc *AlphaController) TokensServer(w http.ResponseWriter, r *http.Request) {
+	result, err := rc.executor.Tokens(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(result); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (rc *AlphaController) ASTServer(w http.ResponseWriter, r *http.Request) {
+	result, err := rc.executor.AST(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(result); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (rc *AlphaController) AlgebraServer(w http.ResponseWriter, r *http.Request) {
+	result, err := rc.executor.Algebra(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(result); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (rc *AlphaController) SQLServer(w http.ResponseWriter, r *http.Request) {
+	result, err := rc.executor.SQL(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(result); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (rc *AlphaController) SQLCli(path string) error {
+	return rc.executor.SQLCli(path)
+}
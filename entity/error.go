@@ -2,14 +2,25 @@ package entity
 
 import (
 	"fmt"
+	"strings"
 )
 
 type CustomError struct {
-	ErrorType string
-	Message   string
-	Position  Position
+	ErrorType string   `json:"type"`
+	Message   string   `json:"message"`
+	Position  Position `json:"position"`
 }
 
 func (c *CustomError) Error() string {
-	return fmt.Sprintf("%s: %s at %d line", c.ErrorType, c.Message, c.Position.Line)
+	return fmt.Sprintf("%s: %s at %d line, %d column", c.ErrorType, c.Message, c.Position.Line, c.Position.Column)
+}
+
+type CustomErrors []*CustomError
+
+func (c CustomErrors) Error() string {
+	messages := make([]string, len(c))
+	for i, err := range c {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
 }
@@ -63,10 +63,16 @@ func (r *Relation) EqualArity(r2 *Relation, position Position) error {
 	return nil
 }
 
+// RelationEqual reports whether r and r2 have the same rows.
 func (r *Relation) RelationEqual(r2 *Relation) bool {
-	for row1 := range *r {
+	return r.includes(r2) && r2.includes(r)
+}
+
+// includes reports whether every row of r2 is a row of r.
+func (r *Relation) includes(r2 *Relation) bool {
+	for row2 := range *r2 {
 		equal := false
-		for row2 := range *r2 {
+		for row1 := range *r {
 			if row1.RowsEqual(row2) {
 				equal = true
 			}
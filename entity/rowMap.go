@@ -17,6 +17,26 @@ func (r *RowMap) RowsEqual(r2 *RowMap) bool {
 	return true
 }
 
+func (r *RowMap) Copy() *RowMap {
+	row := make(RowMap, len(*r))
+	for key, values := range *r {
+		row[key] = append([]string(nil), values...)
+	}
+	return &row
+}
+
+// Agrees reports whether two rows have equal values of the attributes they
+// share.
+func (r *RowMap) Agrees(r2 *RowMap) bool {
+	for key, values := range *r2 {
+		rowValues, exists := (*r)[key]
+		if exists && !OrderedSlicesEqual(values, rowValues) {
+			return false
+		}
+	}
+	return true
+}
+
 func (r *RowMap) keysEqual(r2 *RowMap) bool {
 	if len(*r) != len(*r2) {
 		return false
@@ -0,0 +1,162 @@
+package entity
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+type AttributeType string
+
+const (
+	UnknownType  AttributeType = ""
+	StringType   AttributeType = "string"
+	NumberType   AttributeType = "number"
+	DateType     AttributeType = "date"
+	DateTimeType AttributeType = "datetime"
+	TimeType     AttributeType = "time"
+)
+
+type SchemaAttribute struct {
+	Name     string        `json:"name"`
+	Type     AttributeType `json:"type"`
+	Nullable bool          `json:"nullable"`
+}
+
+type Schema []SchemaAttribute
+type Schemas map[string]*Schema
+
+// NewSchemas returns a schema for every relation: the declared one after
+// checking the data against it, or one inferred from the data otherwise.
+func NewSchemas(relations Relations, declared Schemas) (Schemas, error) {
+	schemas := make(Schemas)
+	for name, relation := range relations {
+		schema, exists := declared[name]
+		if !exists {
+			schemas[name] = InferSchema(relation)
+			continue
+		}
+
+		if err := schema.Validate(name, relation); err != nil {
+			return nil, err
+		}
+
+		schemas[name] = schema
+	}
+	return schemas, nil
+}
+
+func InferSchema(relation *Relation) *Schema {
+	types := make(map[string]AttributeType)
+	counts := make(map[string]int)
+	nullable := make(map[string]bool)
+	for row := range *relation {
+		for name, values := range *row {
+			counts[name]++
+			if len(values) == 0 {
+				nullable[name] = true
+			}
+
+			for _, value := range values {
+				types[name] = commonType(types[name], InferType(value))
+			}
+		}
+	}
+
+	schema := make(Schema, 0, len(counts))
+	for name, count := range counts {
+		schema = append(schema, SchemaAttribute{
+			Name:     name,
+			Type:     types[name],
+			Nullable: nullable[name] || count < len(*relation),
+		})
+	}
+
+	slices.SortFunc(schema, func(a, b SchemaAttribute) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return &schema
+}
+
+func InferType(value string) AttributeType {
+	if _, err := strconv.ParseFloat(value, 10); err == nil {
+		return NumberType
+	}
+
+	if temporal, isTemporal := ParseTemporal(value); isTemporal {
+		switch temporal.Kind {
+		case DateKind:
+			return DateType
+		case TimeKind:
+			return TimeType
+		default:
+			return DateTimeType
+		}
+	}
+
+	return StringType
+}
+
+func commonType(current, next AttributeType) AttributeType {
+	if current == UnknownType || current == next {
+		return next
+	}
+
+	if isDateType(current) && isDateType(next) {
+		return DateTimeType
+	}
+
+	return StringType
+}
+
+func isDateType(attributeType AttributeType) bool {
+	return attributeType == DateType || attributeType == DateTimeType
+}
+
+// TypesCompatible reports whether values of the two types can be compared.
+// Dates and datetimes compare as instants; an unknown type matches anything.
+func TypesCompatible(left, right AttributeType) bool {
+	return left == UnknownType || right == UnknownType || left == right ||
+		isDateType(left) && isDateType(right)
+}
+
+func (s *Schema) Attribute(name string) (SchemaAttribute, bool) {
+	for _, attribute := range *s {
+		if attribute.Name == name {
+			return attribute, true
+		}
+	}
+	return SchemaAttribute{}, false
+}
+
+func (s *Schema) Validate(name string, relation *Relation) error {
+	for row := range *relation {
+		for attributeName := range *row {
+			if _, exists := s.Attribute(attributeName); !exists {
+				return s.error("attribute %s.%s isn't declared in the schema", name, attributeName)
+			}
+		}
+
+		for _, attribute := range *s {
+			values, exists := (*row)[attribute.Name]
+			if (!exists || len(values) == 0) && !attribute.Nullable {
+				return s.error("attribute %s.%s isn't nullable", name, attribute.Name)
+			}
+
+			for _, value := range values {
+				if attribute.Type != StringType && !TypesCompatible(attribute.Type, InferType(value)) {
+					return s.error("value %s of %s.%s isn't a %s", value, name, attribute.Name, attribute.Type)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (*Schema) error(format string, args ...any) error {
+	return &CustomError{
+		ErrorType: ResponseTypes["CF"],
+		Message:   fmt.Sprintf(format, args...),
+	}
+}
@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func relations(t *testing.T, data string) Relations {
+	t.Helper()
+	var result Relations
+	if err := json.Unmarshal([]byte(data), &result); err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+func TestInferSchema(t *testing.T) {
+	data := `{"EMP": [
+		{"id": ["1"], "name": ["ann"], "hired": ["2000-02-01"], "seen": ["2000-02-01 10:00:00"]},
+		{"id": ["2"], "name": ["7"], "hired": ["2001-03-05"]}
+	]}`
+	schemas, err := NewSchemas(relations(t, data), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]SchemaAttribute{
+		"id":    {"id", NumberType, false},
+		"name":  {"name", StringType, false},
+		"hired": {"hired", DateType, false},
+		"seen":  {"seen", DateTimeType, true},
+	}
+	for name, attribute := range expected {
+		if actual, exists := schemas["EMP"].Attribute(name); !exists || actual != attribute {
+			t.Errorf("%s: got %v, want %v", name, actual, attribute)
+		}
+	}
+}
+
+func TestDeclaredSchemaIsValidated(t *testing.T) {
+	declared := Schemas{"EMP": &Schema{{"id", NumberType, false}, {"name", StringType, true}}}
+	tests := []struct {
+		data    string
+		message string
+	}{
+		{`{"EMP": [{"id": ["1"], "name": ["ann"]}, {"id": ["2"]}]}`, ""},
+		{`{"EMP": [{"id": ["x"], "name": ["ann"]}]}`, "value x of EMP.id isn't a number"},
+		{`{"EMP": [{"name": ["ann"]}]}`, "attribute EMP.id isn't nullable"},
+		{`{"EMP": [{"id": ["1"], "dept": ["d1"]}]}`, "attribute EMP.dept isn't declared in the schema"},
+	}
+
+	for _, test := range tests {
+		_, err := NewSchemas(relations(t, test.data), declared)
+		if test.message == "" && err != nil || test.message != "" && (err == nil || !strings.Contains(err.Error(), test.message)) {
+			t.Errorf("%s: got %v, want %q", test.data, err, test.message)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"time"
+)
+
+type TemporalKind int
+
+const (
+	DateKind TemporalKind = iota
+	TimeKind
+	DateTimeKind
+)
+
+type Temporal struct {
+	Kind  TemporalKind
+	Value time.Time
+}
+
+type TemporalFormat struct {
+	Layout string
+	Kind   TemporalKind
+}
+
+// TemporalFormats lists the layouts constants and attribute values are
+// parsed with, tried in order. Values without an offset are read as UTC.
+var TemporalFormats = NewTemporalFormats([]string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02T15:04:05",
+	time.DateTime,
+	"2006-01-02 15:04",
+	time.DateOnly,
+	"02.01.2006",
+	"15:04:05Z07:00",
+	time.TimeOnly,
+	"15:04",
+})
+
+// NewTemporalFormats derives the kind of every layout from the reference
+// components it formats: a layout has a date if two days at the same time of
+// day come out differently, and a time if two times of the same day do.
+func NewTemporalFormats(layouts []string) []TemporalFormat {
+	formats := make([]TemporalFormat, 0, len(layouts))
+	for _, layout := range layouts {
+		hasDate := time.Date(2001, 2, 3, 0, 0, 0, 0, time.UTC).Format(layout) !=
+			time.Date(2004, 5, 6, 0, 0, 0, 0, time.UTC).Format(layout)
+		hasTime := time.Date(2001, 2, 3, 10, 20, 30, 0, time.UTC).Format(layout) !=
+			time.Date(2001, 2, 3, 23, 41, 52, 0, time.UTC).Format(layout)
+		kind := DateTimeKind
+		if !hasTime {
+			kind = DateKind
+		} else if !hasDate {
+			kind = TimeKind
+		}
+
+		formats = append(formats, TemporalFormat{Layout: layout, Kind: kind})
+	}
+	return formats
+}
+
+func ParseTemporal(value string) (Temporal, bool) {
+	for _, format := range TemporalFormats {
+		if parsed, err := time.Parse(format.Layout, value); err == nil {
+			return Temporal{Kind: format.Kind, Value: parsed}, true
+		}
+	}
+	return Temporal{}, false
+}
+
+// Compare orders two temporal values. Dates and datetimes are compared as
+// instants, a date standing for its midnight; times of day are only
+// comparable with each other.
+func (t Temporal) Compare(t2 Temporal) (int, bool) {
+	if (t.Kind == TimeKind) != (t2.Kind == TimeKind) {
+		return 0, false
+	}
+
+	return t.Value.Compare(t2.Value), true
+}
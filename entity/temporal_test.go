@@ -0,0 +1,95 @@
+package entity
+
+import "testing"
+
+func TestParseTemporalKinds(t *testing.T) {
+	tests := []struct {
+		value string
+		kind  TemporalKind
+	}{
+		{"2000-02-01", DateKind},
+		{"01.02.2000", DateKind},
+		{"2000-02-01 10:30:00", DateTimeKind},
+		{"2000-02-01T10:30:00+03:00", DateTimeKind},
+		{"2000-02-01 10:30", DateTimeKind},
+		{"10:30:00", TimeKind},
+		{"10:30", TimeKind},
+	}
+
+	for _, test := range tests {
+		value, isTemporal := ParseTemporal(test.value)
+		if !isTemporal || value.Kind != test.kind {
+			t.Errorf("%s: got %v %v, want kind %d", test.value, value, isTemporal, test.kind)
+		}
+	}
+
+	for _, value := range []string{"ann", "100", "2000-13-01", "25:00"} {
+		if _, isTemporal := ParseTemporal(value); isTemporal {
+			t.Errorf("%s: got a temporal value", value)
+		}
+	}
+}
+
+func TestTemporalCompare(t *testing.T) {
+	tests := []struct {
+		left       string
+		right      string
+		comparison int
+		comparable bool
+	}{
+		{"01.12.2000", "05.03.2001", -1, true},
+		{"2000-02-01", "2000-02-01 00:00:00", 0, true},
+		{"2000-02-01", "2000-02-01 00:00:01", -1, true},
+		{"2000-02-01 12:00:00+03:00", "2000-02-01 10:00:00", -1, true},
+		{"10:30", "09:45:00", 1, true},
+		{"10:30", "2000-02-01", 0, false},
+	}
+
+	for _, test := range tests {
+		left, _ := ParseTemporal(test.left)
+		right, _ := ParseTemporal(test.right)
+		comparison, comparable := left.Compare(right)
+		if comparison != test.comparison || comparable != test.comparable {
+			t.Errorf("%s, %s: got %d %v, want %d %v", test.left, test.right, comparison, comparable, test.comparison, test.comparable)
+		}
+	}
+}
+
+func TestNewTemporalFormatsKinds(t *testing.T) {
+	tests := []struct {
+		layout string
+		kind   TemporalKind
+	}{
+		{"2006-01-02", DateKind},
+		{"02.01.06", DateKind},
+		{"Jan 2, 2006", DateKind},
+		{"2006-002", DateKind},
+		{"15:04", TimeKind},
+		{"3:04pm", TimeKind},
+		{"3:04:05 PM", TimeKind},
+		{"2006-01-02 15:04:05", DateTimeKind},
+		{"1/2/06 3:04pm", DateTimeKind},
+	}
+
+	for _, test := range tests {
+		if kind := NewTemporalFormats([]string{test.layout})[0].Kind; kind != test.kind {
+			t.Errorf("%s: got kind %d, want %d", test.layout, kind, test.kind)
+		}
+	}
+}
+
+func TestParseTemporalWithConfiguredFormats(t *testing.T) {
+	defaults := TemporalFormats
+	defer func() { TemporalFormats = defaults }()
+
+	TemporalFormats = NewTemporalFormats([]string{"3:04pm"})
+	value, isTemporal := ParseTemporal("9:30am")
+	if !isTemporal || value.Kind != TimeKind {
+		t.Fatalf("got %v %v, want a time", value, isTemporal)
+	}
+
+	later, _ := ParseTemporal("1:15pm")
+	if comparison, comparable := value.Compare(later); !comparable || comparison >= 0 {
+		t.Errorf("got %d %v, want 9:30am before 1:15pm", comparison, comparable)
+	}
+}
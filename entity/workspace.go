@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"encoding/json"
+)
+
+type Workspace struct {
+	Relation *Relation
+	Order    []*RowMap
+}
+
+type Workspaces map[string]*Workspace
+
+func NewWorkspace(relation *Relation, order []*RowMap) *Workspace {
+	return &Workspace{
+		Relation: relation,
+		Order:    order,
+	}
+}
+
+func (w *Workspace) IsOrdered() bool {
+	return w.Order != nil
+}
+
+func (w *Workspace) MarshalJSON() ([]byte, error) {
+	if w.IsOrdered() {
+		return json.Marshal(w.Order)
+	}
+
+	return json.Marshal(w.Relation)
+}
+
+func (w *Workspace) UnmarshalJSON(data []byte) error {
+	var rows []*RowMap
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return err
+	}
+
+	relation := make(Relation)
+	for _, row := range rows {
+		relation[row] = struct{}{}
+	}
+
+	w.Relation = &relation
+	w.Order = rows
+	return nil
+}
+
+func (w *Workspace) OrderEqual(w2 *Workspace) bool {
+	if len(w.Order) != len(w2.Order) {
+		return false
+	}
+
+	for index := range w.Order {
+		if !w.Order[index].RowsEqual(w2.Order[index]) {
+			return false
+		}
+	}
+	return true
+}
+
+// WorkspacesEqual reports whether every expected workspace in w is in the
+// actual result under its name with the same rows. Ordered results are
+// compared row by row, unordered ones as sets; further workspaces of the
+// actual result are not checked.
+func (w *Workspaces) WorkspacesEqual(actual *Workspaces) bool {
+	for name, expected := range *w {
+		workspace, exists := (*actual)[name]
+		if !exists {
+			return false
+		}
+
+		if workspace.IsOrdered() && !expected.OrderEqual(workspace) ||
+			!workspace.IsOrdered() && !expected.Relation.RelationEqual(workspace.Relation) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func workspaces(t *testing.T, data string) *Workspaces {
+	t.Helper()
+	var result Workspaces
+	if err := json.Unmarshal([]byte(data), &result); err != nil {
+		t.Fatal(err)
+	}
+
+	return &result
+}
+
+// unordered drops the order of every workspace, as the interpreter leaves
+// unsorted GET results.
+func unordered(w *Workspaces) *Workspaces {
+	for _, workspace := range *w {
+		workspace.Order = nil
+	}
+
+	return w
+}
+
+func TestWorkspaceJSONKeepsOrder(t *testing.T) {
+	data := `{"W":[{"a":["2"]},{"a":["1"]},{"a":["3"]}]}`
+	ordered, err := json.Marshal(workspaces(t, data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(ordered) != data {
+		t.Errorf("got %s, want %s", ordered, data)
+	}
+
+	rows, err := json.Marshal(unordered(workspaces(t, `{"W":[{"a":["2"]}]}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(rows) != `{"W":[{"a":["2"]}]}` {
+		t.Errorf("got %s, want the unordered rows as an array", rows)
+	}
+}
+
+func TestWorkspacesEqual(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		actual   string
+		ordered  bool
+		equal    bool
+	}{
+		{"same rows in another order", `{"W": [{"a": ["1"]}, {"a": ["2"]}]}`, `{"W": [{"a": ["2"]}, {"a": ["1"]}]}`, false, true},
+		{"extra rows", `{"W": [{"a": ["1"]}]}`, `{"W": [{"a": ["1"]}, {"a": ["2"]}]}`, false, false},
+		{"missing rows", `{"W": [{"a": ["1"]}, {"a": ["2"]}]}`, `{"W": [{"a": ["1"]}]}`, false, false},
+		{"empty expected workspace", `{"W": []}`, `{"W": [{"a": ["1"]}]}`, false, false},
+		{"both empty", `{"W": []}`, `{"W": []}`, false, true},
+		{"missing workspace", `{"W": [{"a": ["1"]}]}`, `{"V": [{"a": ["1"]}]}`, false, false},
+		{"workspaces matched by name", `{"W": [{"a": ["1"]}], "V": [{"a": ["2"]}]}`, `{"W": [{"a": ["2"]}], "V": [{"a": ["1"]}]}`, false, false},
+		{"further actual workspace", `{"W": [{"a": ["1"]}]}`, `{"W": [{"a": ["1"]}], "V": [{"a": ["2"]}]}`, false, true},
+		{"same order", `{"W": [{"a": ["1"]}, {"a": ["2"]}]}`, `{"W": [{"a": ["1"]}, {"a": ["2"]}]}`, true, true},
+		{"other order", `{"W": [{"a": ["1"]}, {"a": ["2"]}]}`, `{"W": [{"a": ["2"]}, {"a": ["1"]}]}`, true, false},
+		{"ordered extra rows", `{"W": [{"a": ["1"]}]}`, `{"W": [{"a": ["1"]}, {"a": ["2"]}]}`, true, false},
+	}
+
+	for _, test := range tests {
+		actual := workspaces(t, test.actual)
+		if !test.ordered {
+			actual = unordered(actual)
+		}
+
+		if equal := workspaces(t, test.expected).WorkspacesEqual(actual); equal != test.equal {
+			t.Errorf("%s: got %t, want %t", test.name, equal, test.equal)
+		}
+	}
+}
@@ -3,31 +3,44 @@ package main
 import (
 	"alpha-executor/controller"
 	"alpha-executor/entity"
+	"alpha-executor/model"
 	"alpha-executor/repository"
 	"alpha-executor/router"
 	"alpha-executor/service"
 	"flag"
+	"log"
 )
 
 func main() {
 	var isCli bool
 	flag.BoolVar(&isCli, "cli", false, "launch a command line app")
-	flag.String("config-path", "", "config file location")
+	configPath := flag.String("config-path", "", "config file location")
 	flag.Bool("validation", false, "executes validation if true, testing if false")
+	formatPath := flag.String("fmt", "", "prints the ALPHA program in the file in canonical form")
+	sqlPath := flag.String("sql", "", "prints the ALPHA program and relations in the JSON file as SQL")
 	flag.Parse()
 
+	if *configPath != "" {
+		if err := model.SetDateFormats(*configPath); err != nil {
+			log.Fatalf("Fail to read file: %v", err)
+		}
+	}
+
 	alphaRepository := repository.NewAlphaRepository(
 		make(entity.RowsMap),
 		make(entity.Relations),
 		make(entity.Relations),
 		make(entity.Relations),
-		make(entity.Relations),
+		make(map[string]string),
+		make(map[string][]*entity.RowMap),
+		make(entity.Workspaces),
+		make(map[string]int),
 	)
 	alphaService := service.NewAlphaService(alphaRepository)
 	alphaController := controller.NewAlphaController(alphaService)
 
 	requestRouter := router.NewRouter(alphaController)
-	if isCli {
+	if isCli || *formatPath != "" || *sqlPath != "" {
 		requestRouter.Cli()
 	} else {
 		requestRouter.Server()
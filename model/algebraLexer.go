@@ -0,0 +1,298 @@
+package model
+
+import (
+	"alpha-executor/entity"
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+var algebraKeywords = map[string]LexType{
+	"SELECT":    SELECTION,
+	"PROJECT":   PROJECTION,
+	"RENAME":    RENAME,
+	"JOIN":      JOIN,
+	"TIMES":     PRODUCT,
+	"UNION":     UNION,
+	"INTERSECT": INTERSECTION,
+	"MINUS":     DIFFERENCE,
+	"DIVIDE":    DIVISION,
+}
+
+// AlgebraLexer splits relational algebra statements such as
+//
+//	W := π[EMP.name](σ[salary > 95](EMP) ⋈ DEPT)
+//
+// into tokens. Every operator has an ASCII spelling: SELECT, PROJECT,
+// RENAME, JOIN, TIMES or *, UNION, INTERSECT, MINUS or -, DIVIDE or /.
+// Conditions of selections and joins are ALPHA qualifications and are lexed
+// by Lexer, with their positions kept relative to the whole source.
+type AlgebraLexer struct {
+	pos     entity.Position
+	reader  *bufio.Reader
+	results [][]*Token
+	errors  []*entity.CustomError
+}
+
+func NewAlgebraLexer(reader *bufio.Reader) *AlgebraLexer {
+	return &AlgebraLexer{
+		pos:     entity.Position{Line: 1, Column: 0},
+		reader:  reader,
+		results: make([][]*Token, 0),
+		errors:  make([]*entity.CustomError, 0),
+	}
+}
+
+// Errors returns the lexical errors found by Lex.
+func (l *AlgebraLexer) Errors() []*entity.CustomError {
+	return l.errors
+}
+
+func (l *AlgebraLexer) Lex() [][]*Token {
+	result := make([]*Token, 0)
+	for {
+		r, _, err := l.reader.ReadRune()
+		if err != nil {
+			if err == io.EOF {
+				l.results = append(l.results, result)
+				return l.results
+			}
+
+			panic(err)
+		}
+
+		l.pos.Column++
+		start := l.pos
+
+		switch r {
+		case ';':
+			l.results = append(l.results, result)
+			result = make([]*Token, 0)
+		case '\n':
+			l.nextLine()
+		case 'σ', 'π', 'ρ', '⋈', '×', '∪', '∩', '−', '÷':
+			result = append(result, &Token{l.operator(r), string(r), start})
+		case '*':
+			result = append(result, &Token{PRODUCT, string(r), start})
+		case '/':
+			result = append(result, &Token{DIVISION, string(r), start})
+		case '-':
+			if l.nextIs('-') {
+				l.skipLineComment()
+				break
+			}
+
+			if l.nextIs('>') {
+				l.read()
+				result = append(result, &Token{IMPLICATION, "->", start})
+				break
+			}
+
+			result = append(result, &Token{DIFFERENCE, string(r), start})
+		case '→':
+			result = append(result, &Token{IMPLICATION, string(r), start})
+		case ':':
+			if !l.nextIs('=') {
+				result = l.illegal(result, string(r), start, fmt.Sprintf("illegal character %q", r))
+				break
+			}
+
+			l.read()
+			result = append(result, &Token{DEFINE, DEFINE.String(), start})
+		case '(':
+			result = append(result, &Token{LEFT_PARENTHESIS, LEFT_PARENTHESIS.String(), start})
+		case ')':
+			result = append(result, &Token{RIGHT_PARENTHESIS, RIGHT_PARENTHESIS.String(), start})
+		case ',':
+			result = append(result, &Token{COMMA, COMMA.String(), start})
+		case '[':
+			result = append(result, &Token{LEFT_BRACKET, LEFT_BRACKET.String(), start})
+			if last := len(result) - 2; last >= 0 && (result[last].Type == SELECTION || result[last].Type == JOIN) {
+				result = l.lexCondition(result, start)
+			}
+		case ']':
+			result = append(result, &Token{RIGHT_BRACKET, RIGHT_BRACKET.String(), start})
+		default:
+			if unicode.IsSpace(r) {
+				continue
+			}
+
+			if !unicode.IsLetter(r) && r != '_' {
+				result = l.illegal(result, string(r), start, fmt.Sprintf("illegal character %q", r))
+				break
+			}
+
+			lit := l.lexName(r)
+			if lexType, isKeyword := algebraKeywords[lit]; isKeyword {
+				result = append(result, &Token{lexType, lit, start})
+				break
+			}
+
+			switch strings.Count(lit, ".") {
+			case 0:
+				result = append(result, &Token{FREE_RELATION, lit, start})
+			case 1:
+				result = append(result, &Token{ATTRIBUTE, lit, start})
+			default:
+				result = l.illegal(result, lit, start, fmt.Sprintf("malformed identifier %q", lit))
+			}
+		}
+	}
+}
+
+func (*AlgebraLexer) operator(r rune) LexType {
+	for _, lexType := range []LexType{SELECTION, PROJECTION, RENAME, JOIN, PRODUCT, UNION, INTERSECTION, DIFFERENCE, DIVISION} {
+		if lexType.String() == string(r) {
+			return lexType
+		}
+	}
+
+	return ILLEGAL
+}
+
+// lexName scans a relation, variable or attribute name; X.* stands for all
+// attributes of X.
+func (l *AlgebraLexer) lexName(first rune) string {
+	lit := string(first)
+	for {
+		next, _, err := l.reader.ReadRune()
+		if err != nil {
+			return lit
+		}
+
+		if unicode.IsLetter(next) || unicode.IsDigit(next) || next == '_' || next == '.' ||
+			next == '*' && strings.HasSuffix(lit, ".") {
+			l.pos.Column++
+			lit += string(next)
+			continue
+		}
+
+		if err = l.reader.UnreadRune(); err != nil {
+			panic(err)
+		}
+
+		return lit
+	}
+}
+
+// lexCondition lexes the condition between the bracket just read and its
+// closing bracket as an ALPHA qualification.
+func (l *AlgebraLexer) lexCondition(result []*Token, open entity.Position) []*Token {
+	var condition strings.Builder
+	quoted, escaped, depth := false, false, 0
+	for {
+		r, _, err := l.reader.ReadRune()
+		if err != nil {
+			return l.illegal(result, condition.String(), open, "unterminated condition")
+		}
+
+		if r == '\n' {
+			l.nextLine()
+		} else {
+			l.pos.Column++
+		}
+
+		switch {
+		case escaped:
+			escaped = false
+		case quoted:
+			escaped = r == '\\'
+			quoted = r != '"'
+		case r == '"':
+			quoted = true
+		case r == '[':
+			depth++
+		case r == ']' && depth == 0:
+			result = append(result, l.lexQualification(condition.String(), open)...)
+			return append(result, &Token{RIGHT_BRACKET, RIGHT_BRACKET.String(), l.pos})
+		case r == ']':
+			depth--
+		}
+
+		condition.WriteRune(r)
+	}
+}
+
+func (l *AlgebraLexer) lexQualification(condition string, open entity.Position) []*Token {
+	shift := func(position entity.Position) entity.Position {
+		if position.Line == 1 {
+			position.Column += open.Column
+		}
+
+		position.Line += open.Line - 1
+		return position
+	}
+
+	lexer := NewLexer(bufio.NewReader(strings.NewReader(condition)))
+	statements := lexer.Lex()
+	for _, err := range lexer.Errors() {
+		err.Position = shift(err.Position)
+		l.errors = append(l.errors, err)
+	}
+
+	tokens := make([]*Token, 0)
+	for i, statement := range statements {
+		for _, token := range statement {
+			token.Position = shift(token.Position)
+			tokens = append(tokens, token)
+		}
+
+		if i < len(statements)-1 {
+			tokens = l.illegal(tokens, ";", open, "unexpected ; in condition")
+		}
+	}
+
+	return tokens
+}
+
+func (l *AlgebraLexer) nextLine() {
+	l.pos.Line++
+	l.pos.Column = 0
+}
+
+func (l *AlgebraLexer) nextIs(r rune) bool {
+	next, _, err := l.reader.ReadRune()
+	if err != nil {
+		return false
+	}
+
+	if err = l.reader.UnreadRune(); err != nil {
+		panic(err)
+	}
+
+	return next == r
+}
+
+func (l *AlgebraLexer) read() {
+	if _, _, err := l.reader.ReadRune(); err == nil {
+		l.pos.Column++
+	}
+}
+
+func (l *AlgebraLexer) skipLineComment() {
+	for {
+		r, _, err := l.reader.ReadRune()
+		if err != nil {
+			return
+		}
+
+		if r == '\n' {
+			l.nextLine()
+			return
+		}
+
+		l.pos.Column++
+	}
+}
+
+func (l *AlgebraLexer) illegal(result []*Token, lit string, position entity.Position, message string) []*Token {
+	l.errors = append(l.errors, &entity.CustomError{
+		ErrorType: entity.ResponseTypes["CE"],
+		Message:   message,
+		Position:  position,
+	})
+
+	return append(result, &Token{ILLEGAL, lit, position})
+}
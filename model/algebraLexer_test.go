@@ -0,0 +1,86 @@
+package model
+
+import (
+	"bufio"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func lexAlgebra(source string) ([]LexType, []string) {
+	lexer := NewAlgebraLexer(bufio.NewReader(strings.NewReader(source)))
+	types := make([]LexType, 0)
+	for _, statement := range lexer.Lex() {
+		for _, token := range statement {
+			types = append(types, token.Type)
+		}
+	}
+
+	messages := make([]string, 0)
+	for _, err := range lexer.Errors() {
+		messages = append(messages, err.Message)
+	}
+
+	return types, messages
+}
+
+func TestLexAlgebraSpellings(t *testing.T) {
+	tests := []struct {
+		unicode string
+		ascii   []string
+	}{
+		{"W := σ[salary > 95](EMP)", []string{"W := SELECT[salary > 95](EMP)"}},
+		{"W := π[name](EMP)", []string{"W := PROJECT[name](EMP)"}},
+		{"W := ρ[id → key](EMP)", []string{"W := RENAME[id -> key](EMP)"}},
+		{"W := EMP ⋈ DEPT", []string{"W := EMP JOIN DEPT"}},
+		{"W := EMP × DEPT", []string{"W := EMP TIMES DEPT", "W := EMP * DEPT"}},
+		{"W := EMP ∪ DEPT", []string{"W := EMP UNION DEPT"}},
+		{"W := EMP ∩ DEPT", []string{"W := EMP INTERSECT DEPT"}},
+		{"W := EMP − DEPT", []string{"W := EMP MINUS DEPT", "W := EMP - DEPT"}},
+		{"W := EMP ÷ DEPT", []string{"W := EMP DIVIDE DEPT", "W := EMP / DEPT"}},
+	}
+
+	for _, test := range tests {
+		expected, errors := lexAlgebra(test.unicode)
+		if len(errors) > 0 {
+			t.Fatalf("%s: %v", test.unicode, errors)
+		}
+
+		for _, source := range test.ascii {
+			if types, errors := lexAlgebra(source); len(errors) > 0 || !slices.Equal(types, expected) {
+				t.Errorf("%s: got %v %v, want %v", source, types, errors, expected)
+			}
+		}
+	}
+}
+
+func TestLexAlgebraConditions(t *testing.T) {
+	tests := []struct {
+		source   string
+		expected []LexType
+		errors   []string
+	}{
+		{
+			`W := σ[EMP.dept = "a]b"](EMP)`,
+			[]LexType{FREE_RELATION, DEFINE, SELECTION, LEFT_BRACKET, ATTRIBUTE, EQUALS, CONSTANT, RIGHT_BRACKET, LEFT_PARENTHESIS, FREE_RELATION, RIGHT_PARENTHESIS},
+			[]string{},
+		},
+		{
+			"W := σ[salary > 95(EMP)",
+			[]LexType{FREE_RELATION, DEFINE, SELECTION, LEFT_BRACKET, ILLEGAL},
+			[]string{"unterminated condition"},
+		},
+		{
+			"W := σ[salary > 95; GET V (EMP)](EMP)",
+			[]LexType{FREE_RELATION, DEFINE, SELECTION, LEFT_BRACKET, FREE_RELATION, GREATER_THAN, INTEGER, ILLEGAL, GET, FREE_RELATION, LEFT_PARENTHESIS, FREE_RELATION, RIGHT_PARENTHESIS, RIGHT_BRACKET, LEFT_PARENTHESIS, FREE_RELATION, RIGHT_PARENTHESIS},
+			[]string{"unexpected ; in condition"},
+		},
+	}
+
+	for _, test := range tests {
+		types, errors := lexAlgebra(test.source)
+		if !slices.Equal(types, test.expected) || !slices.Equal(errors, test.errors) {
+			t.Errorf("%s: got %v %v, want %v %v", test.source, types, errors, test.expected, test.errors)
+		}
+	}
+}
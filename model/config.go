@@ -1,6 +1,7 @@
 package model
 
 import (
+	"alpha-executor/entity"
 	"flag"
 	"gopkg.in/ini.v1"
 	"log"
@@ -36,3 +37,19 @@ func GetConfig() (*Config, error) {
 	}
 	return data, err
 }
+
+// SetDateFormats makes the date_formats of a configuration file the layouts
+// temporal values are parsed with. It is called once at startup, before any
+// request is served, so that executions and validations parse values alike.
+func SetDateFormats(path string) error {
+	inidata, err := ini.Load(path)
+	if err != nil {
+		return err
+	}
+
+	formats := inidata.Section("").Key("date_formats").Strings("|")
+	if len(formats) > 0 {
+		entity.TemporalFormats = entity.NewTemporalFormats(formats)
+	}
+	return nil
+}
@@ -3,8 +3,11 @@ package model
 import (
 	"alpha-executor/entity"
 	"bufio"
+	"fmt"
 	"io"
 	"slices"
+	"strconv"
+	"strings"
 	"unicode"
 )
 
@@ -14,6 +17,10 @@ func (t LexType) String() string {
 	return tokens[t]
 }
 
+func (t LexType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
 const (
 	EOF LexType = iota
 
@@ -23,6 +30,7 @@ const (
 	ATTRIBUTE
 	CONSTANT
 	INTEGER
+	FLOAT
 	DATE
 	NULL
 	FREE_RELATION
@@ -39,6 +47,17 @@ const (
 	DOWN
 	UP
 
+	COUNT
+	TOTAL
+	MAX
+	MIN
+	AVG
+	WHERE
+
+	IS
+	NOT
+	AND
+
 	ASSIGN
 
 	EQUALS
@@ -47,6 +66,18 @@ const (
 	LESS_THAN_EQUALS
 	GREATER_THAN
 	GREATER_THAN_EQUALS
+	IS_NULL
+	IS_NOT_NULL
+	IN
+	BETWEEN
+	LIKE
+	MATCH
+
+	PLUS
+	MINUS
+	MULTIPLY
+	DIVIDE
+	UNARY_MINUS
 
 	EXISTS
 	FOR_ALL
@@ -60,6 +91,30 @@ const (
 	RIGHT_PARENTHESIS
 	COMMA
 	LOGIC_START
+
+	SELECTION
+	PROJECTION
+	RENAME
+	JOIN
+	PRODUCT
+	UNION
+	INTERSECTION
+	DIFFERENCE
+	DIVISION
+	DEFINE
+	LEFT_BRACKET
+	RIGHT_BRACKET
+
+	SELECT
+	FROM
+	GROUP
+	ORDER
+	BY
+	LIMIT
+	DISTINCT
+	AS
+	CREATE
+	VIEW
 )
 
 var tokens = []string{
@@ -71,6 +126,7 @@ var tokens = []string{
 	ATTRIBUTE:     "ATTRIBUTE",
 	CONSTANT:      "CONSTANT",
 	INTEGER:       "INTEGER",
+	FLOAT:         "FLOAT",
 	DATE:          "DATE",
 	NULL:          "NULL",
 	FREE_RELATION: "FREE_RELATION",
@@ -87,6 +143,17 @@ var tokens = []string{
 	DOWN: "DOWN",
 	UP:   "UP",
 
+	COUNT: "COUNT",
+	TOTAL: "TOTAL",
+	MAX:   "MAX",
+	MIN:   "MIN",
+	AVG:   "AVG",
+	WHERE: "WHERE",
+
+	IS:  "IS",
+	NOT: "NOT",
+	AND: "AND",
+
 	ASSIGN: "ASSIGN",
 
 	EQUALS:              "=",
@@ -95,6 +162,18 @@ var tokens = []string{
 	LESS_THAN_EQUALS:    "<=",
 	GREATER_THAN:        ">",
 	GREATER_THAN_EQUALS: ">=",
+	IS_NULL:             "IS NULL",
+	IS_NOT_NULL:         "IS NOT NULL",
+	IN:                  "IN",
+	BETWEEN:             "BETWEEN",
+	LIKE:                "LIKE",
+	MATCH:               "~",
+
+	PLUS:        "+",
+	MINUS:       "-",
+	MULTIPLY:    "*",
+	DIVIDE:      "/",
+	UNARY_MINUS: "UNARY_MINUS",
 
 	EXISTS:  "∃", //какие-то кортежи удовлетворяют условию
 	FOR_ALL: "∀", //     все
@@ -108,18 +187,43 @@ var tokens = []string{
 	RIGHT_PARENTHESIS: ")",
 	COMMA:             ",",
 	LOGIC_START:       ":",
+
+	SELECTION:     "σ",
+	PROJECTION:    "π",
+	RENAME:        "ρ",
+	JOIN:          "⋈",
+	PRODUCT:       "×",
+	UNION:         "∪",
+	INTERSECTION:  "∩",
+	DIFFERENCE:    "−",
+	DIVISION:      "÷",
+	DEFINE:        ":=",
+	LEFT_BRACKET:  "[",
+	RIGHT_BRACKET: "]",
+
+	SELECT:   "SELECT",
+	FROM:     "FROM",
+	GROUP:    "GROUP",
+	ORDER:    "ORDER",
+	BY:       "BY",
+	LIMIT:    "LIMIT",
+	DISTINCT: "DISTINCT",
+	AS:       "AS",
+	CREATE:   "CREATE",
+	VIEW:     "VIEW",
 }
 
 type Token struct {
-	Type     LexType
-	Value    string
-	Position entity.Position
+	Type     LexType         `json:"type"`
+	Value    string          `json:"value"`
+	Position entity.Position `json:"position"`
 }
 
 type Lexer struct {
 	pos     entity.Position
 	reader  *bufio.Reader
 	results [][]*Token
+	errors  []*entity.CustomError
 }
 
 func NewLexer(reader *bufio.Reader) *Lexer {
@@ -127,9 +231,16 @@ func NewLexer(reader *bufio.Reader) *Lexer {
 		pos:     entity.Position{Line: 1, Column: 0},
 		reader:  reader,
 		results: make([][]*Token, 0),
+		errors:  make([]*entity.CustomError, 0),
 	}
 }
 
+// Errors returns the lexical errors found by Lex. Every one of them has a
+// matching ILLEGAL token in the statement it was found in.
+func (l *Lexer) Errors() []*entity.CustomError {
+	return l.errors
+}
+
 func (l *Lexer) Lex() [][]*Token {
 	result := make([]*Token, 0)
 	for {
@@ -144,6 +255,7 @@ func (l *Lexer) Lex() [][]*Token {
 		}
 
 		l.pos.Column++
+		start := l.pos
 
 		if r == ';' {
 			l.results = append(l.results, result)
@@ -155,59 +267,97 @@ func (l *Lexer) Lex() [][]*Token {
 		case '\n':
 			l.nextLine()
 		case '=':
-			result = append(result, &Token{EQUALS, EQUALS.String(), l.pos})
+			result = append(result, &Token{EQUALS, EQUALS.String(), start})
 			break
 		case '∃':
-			result = append(result, &Token{EXISTS, EXISTS.String(), l.pos})
+			result = append(result, &Token{EXISTS, EXISTS.String(), start})
 			break
 		case '∀':
-			result = append(result, &Token{FOR_ALL, FOR_ALL.String(), l.pos})
+			result = append(result, &Token{FOR_ALL, FOR_ALL.String(), start})
 			break
 		case '¬':
-			result = append(result, &Token{NEGATION, NEGATION.String(), l.pos})
+			result = append(result, &Token{NEGATION, NEGATION.String(), start})
 			break
 		case '∧':
-			result = append(result, &Token{CONJUNCTION, CONJUNCTION.String(), l.pos})
+			result = append(result, &Token{CONJUNCTION, CONJUNCTION.String(), start})
 			break
 		case '∨':
-			result = append(result, &Token{DISJUNCTION, DISJUNCTION.String(), l.pos})
+			result = append(result, &Token{DISJUNCTION, DISJUNCTION.String(), start})
+			break
+		case '→':
+			result = append(result, &Token{IMPLICATION, IMPLICATION.String(), start})
+			break
+		case '≠':
+			result = append(result, &Token{NOT_EQUALS, NOT_EQUALS.String(), start})
+			break
+		case '≤':
+			result = append(result, &Token{LESS_THAN_EQUALS, LESS_THAN_EQUALS.String(), start})
+			break
+		case '≥':
+			result = append(result, &Token{GREATER_THAN_EQUALS, GREATER_THAN_EQUALS.String(), start})
 			break
 		case '(':
-			result = append(result, &Token{LEFT_PARENTHESIS, LEFT_PARENTHESIS.String(), l.pos})
+			result = append(result, &Token{LEFT_PARENTHESIS, LEFT_PARENTHESIS.String(), start})
 			break
 		case ')':
-			result = append(result, &Token{RIGHT_PARENTHESIS, RIGHT_PARENTHESIS.String(), l.pos})
+			result = append(result, &Token{RIGHT_PARENTHESIS, RIGHT_PARENTHESIS.String(), start})
 			break
 		case ',':
-			result = append(result, &Token{COMMA, COMMA.String(), l.pos})
+			result = append(result, &Token{COMMA, COMMA.String(), start})
 			break
 		case ':':
-			result = append(result, &Token{LOGIC_START, LOGIC_START.String(), l.pos})
+			result = append(result, &Token{LOGIC_START, LOGIC_START.String(), start})
+			break
+		case '+':
+			result = append(result, &Token{PLUS, PLUS.String(), start})
+			break
+		case '*':
+			result = append(result, &Token{MULTIPLY, MULTIPLY.String(), start})
+			break
+		case '~':
+			result = append(result, &Token{MATCH, MATCH.String(), start})
+			break
+		case '/':
+			if l.nextIs('*') {
+				if !l.skipBlockComment() {
+					result = l.illegal(result, "/*", start, "unterminated block comment")
+				}
+				break
+			}
+
+			result = append(result, &Token{DIVIDE, DIVIDE.String(), start})
 			break
 		case '!', '>', '<', '-':
-			l.backup()
-			lit := l.lexSym()
+			if r == '-' && l.nextIs('-') {
+				l.skipLineComment()
+				break
+			}
+
+			lit := l.lexSym(r)
 			switch lit {
 			case "!=":
-				result = append(result, &Token{NOT_EQUALS, lit, l.pos})
+				result = append(result, &Token{NOT_EQUALS, lit, start})
 				break
 			case ">":
-				result = append(result, &Token{GREATER_THAN, lit, l.pos})
+				result = append(result, &Token{GREATER_THAN, lit, start})
 				break
 			case "<":
-				result = append(result, &Token{LESS_THAN, lit, l.pos})
+				result = append(result, &Token{LESS_THAN, lit, start})
 				break
 			case ">=":
-				result = append(result, &Token{GREATER_THAN_EQUALS, lit, l.pos})
+				result = append(result, &Token{GREATER_THAN_EQUALS, lit, start})
 				break
 			case "<=":
-				result = append(result, &Token{LESS_THAN_EQUALS, lit, l.pos})
+				result = append(result, &Token{LESS_THAN_EQUALS, lit, start})
 				break
 			case "->":
-				result = append(result, &Token{IMPLICATION, lit, l.pos})
+				result = append(result, &Token{IMPLICATION, lit, start})
+				break
+			case "-":
+				result = append(result, &Token{MINUS, lit, start})
 				break
 			default:
-				result = append(result, &Token{ILLEGAL, string(r), l.pos})
+				result = l.illegal(result, string(r), start, fmt.Sprintf("illegal character %q", r))
 				break
 			}
 			break
@@ -217,50 +367,94 @@ func (l *Lexer) Lex() [][]*Token {
 			} else if unicode.IsDigit(r) {
 				l.backup()
 				lit := l.lexInt()
-				result = append(result, &Token{INTEGER, lit, l.pos})
+				if strings.Contains(lit, ".") {
+					result = append(result, &Token{FLOAT, lit, start})
+					break
+				}
+
+				result = append(result, &Token{INTEGER, lit, start})
 				break
 			} else if unicode.IsLetter(r) {
 				l.backup()
-				lit, dot, dash := l.lexStr()
-				if dot == 1 && dash == 0 {
-					result = append(result, &Token{ATTRIBUTE, lit, l.pos})
+				lit, dot := l.lexStr()
+				if dot == 1 {
+					result = append(result, &Token{ATTRIBUTE, lit, start})
 					break
-				} else if dot > 1 && dash != 0 {
-					result = append(result, &Token{ILLEGAL, lit, l.pos})
+				} else if dot > 1 {
+					result = l.illegal(result, lit, start, fmt.Sprintf("malformed identifier %q", lit))
 					break
 				}
 
 				switch lit {
 				case "GET":
-					result = append(result, &Token{GET, lit, l.pos})
+					result = append(result, &Token{GET, lit, start})
 					break
 				case "RANGE":
-					result = append(result, &Token{RANGE, lit, l.pos})
+					result = append(result, &Token{RANGE, lit, start})
 					break
 				case "HOLD":
-					result = append(result, &Token{HOLD, lit, l.pos})
+					result = append(result, &Token{HOLD, lit, start})
 					break
 				case "RELEASE":
-					result = append(result, &Token{RELEASE, lit, l.pos})
+					result = append(result, &Token{RELEASE, lit, start})
 					break
 				case "UPDATE":
-					result = append(result, &Token{UPDATE, lit, l.pos})
+					result = append(result, &Token{UPDATE, lit, start})
 					break
 				case "DELETE":
-					result = append(result, &Token{DELETE, lit, l.pos})
+					result = append(result, &Token{DELETE, lit, start})
 					break
 				case "PUT":
-					result = append(result, &Token{PUT, lit, l.pos})
+					result = append(result, &Token{PUT, lit, start})
 					break
 				case "DOWN":
-					result = append(result, &Token{DOWN, lit, l.pos})
+					result = append(result, &Token{DOWN, lit, start})
 					break
 				case "UP":
-					result = append(result, &Token{UP, lit, l.pos})
+					result = append(result, &Token{UP, lit, start})
+					break
+				case "COUNT":
+					result = append(result, &Token{COUNT, lit, start})
+					break
+				case "TOTAL":
+					result = append(result, &Token{TOTAL, lit, start})
+					break
+				case "MAX":
+					result = append(result, &Token{MAX, lit, start})
+					break
+				case "MIN":
+					result = append(result, &Token{MIN, lit, start})
+					break
+				case "AVG":
+					result = append(result, &Token{AVG, lit, start})
+					break
+				case "WHERE":
+					result = append(result, &Token{WHERE, lit, start})
+					break
+				case "IS":
+					result = append(result, &Token{IS, lit, start})
+					break
+				case "NOT":
+					result = append(result, &Token{NOT, lit, start})
+					break
+				case "NULL":
+					result = append(result, &Token{NULL, lit, start})
+					break
+				case "AND":
+					result = append(result, &Token{AND, lit, start})
+					break
+				case "IN":
+					result = append(result, &Token{IN, lit, start})
+					break
+				case "BETWEEN":
+					result = append(result, &Token{BETWEEN, lit, start})
+					break
+				case "LIKE":
+					result = append(result, &Token{LIKE, lit, start})
 					break
 				default:
 					if len(result) > 1 && result[len(result)-1].Type == EXISTS {
-						result = append(result, &Token{BIND_RELATION, lit, l.pos})
+						result = append(result, &Token{BIND_RELATION, lit, start})
 						for _, tokens := range l.results {
 							for _, token := range tokens {
 								if token.Type == FREE_RELATION && token.Value == lit {
@@ -272,26 +466,25 @@ func (l *Lexer) Lex() [][]*Token {
 						break
 					}
 
-					result = append(result, &Token{FREE_RELATION, lit, l.pos})
+					result = append(result, &Token{FREE_RELATION, lit, start})
 					break
 				}
 			} else if r == '"' {
-				l.backup()
-				lit, dot, dash := l.lexStr()
-				if dot > 0 || (dash > 0 && dash != 2) {
-					result = append(result, &Token{ILLEGAL, lit, l.pos})
+				lit, err := l.lexString(start)
+				if err != nil {
+					result = l.illegal(result, lit, start, err.Error())
 					break
 				}
 
-				if dash == 2 {
-					result = append(result, &Token{DATE, lit, l.pos})
+				if _, isDate := entity.ParseTemporal(lit); isDate {
+					result = append(result, &Token{DATE, lit, start})
 					break
 				}
 
-				result = append(result, &Token{CONSTANT, lit, l.pos})
+				result = append(result, &Token{CONSTANT, lit, start})
 				break
 			} else {
-				result = append(result, &Token{ILLEGAL, string(r), l.pos})
+				result = l.illegal(result, string(r), start, fmt.Sprintf("illegal character %q", r))
 				break
 			}
 		}
@@ -313,41 +506,104 @@ func (l *Lexer) backup() {
 
 func (l *Lexer) lexInt() string {
 	lit := ""
+	for {
+		next, _ := l.reader.Peek(2)
+		if len(next) == 0 {
+			return lit
+		}
+
+		fraction := next[0] == '.' && len(next) > 1 && isDigit(next[1]) && !strings.Contains(lit, ".")
+		if !isDigit(next[0]) && !fraction {
+			return lit
+		}
+
+		if _, err := l.reader.ReadByte(); err != nil {
+			return lit
+		}
+
+		l.pos.Column++
+		lit = lit + string(next[0])
+	}
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+func (l *Lexer) nextIs(b byte) bool {
+	next, err := l.reader.Peek(1)
+	return err == nil && next[0] == b
+}
+
+func (l *Lexer) skipLineComment() {
 	for {
 		r, _, err := l.reader.ReadRune()
 		if err != nil {
-			if err == io.EOF {
-				return lit
-			}
+			return
+		}
+
+		if r == '\n' {
+			l.nextLine()
+			return
 		}
 
 		l.pos.Column++
-		if unicode.IsDigit(r) {
-			lit = lit + string(r)
+	}
+}
+
+// skipBlockComment skips a comment up to */, starting at the * of /*, which
+// therefore cannot close the comment itself, and reports whether the comment
+// was closed.
+func (l *Lexer) skipBlockComment() bool {
+	if _, _, err := l.reader.ReadRune(); err != nil {
+		return false
+	}
+
+	l.pos.Column++
+	previous := rune(0)
+	for {
+		r, _, err := l.reader.ReadRune()
+		if err != nil {
+			return false
+		}
+
+		if r == '\n' {
+			l.nextLine()
 		} else {
-			l.backup()
-			return lit
+			l.pos.Column++
+		}
+
+		if r == '/' && previous == '*' {
+			return true
 		}
+
+		previous = r
 	}
 }
 
-func (l *Lexer) lexStr() (string, int, int) {
+func (l *Lexer) illegal(result []*Token, lit string, position entity.Position, message string) []*Token {
+	l.errors = append(l.errors, &entity.CustomError{
+		ErrorType: entity.ResponseTypes["CE"],
+		Message:   message,
+		Position:  position,
+	})
+
+	return append(result, &Token{ILLEGAL, lit, position})
+}
+
+// lexStr scans a name or an attribute R.a. Names may contain - / and \, as
+// in ВРАЧ-ПАЦИЕНТ and ВРАЧ.К/В, so a minus or a slash after a name is an
+// operator only if a space separates it from the name.
+func (l *Lexer) lexStr() (string, int) {
 	lit := ""
 	special := []rune{'.', '-', '/', '\\'}
-
-	quote := 0
 	dot := 0
-	dash := 0
 
 	for {
-		if quote == 2 {
-			return lit, dot, dash
-		}
-
 		r, _, err := l.reader.ReadRune()
 		if err != nil {
 			if err == io.EOF {
-				return lit, dot, dash
+				return lit, dot
 			}
 		}
 
@@ -357,46 +613,98 @@ func (l *Lexer) lexStr() (string, int, int) {
 			if r == '.' {
 				dot += 1
 			}
-
-			if r == '-' {
-				dash += 1
-			}
-		} else if r == '"' {
-			quote++
 		} else {
 			l.backup()
-			return lit, dot, dash
+			return lit, dot
 		}
 	}
 }
 
-func (l *Lexer) lexSym() string {
-	lit := ""
+// lexString scans a quoted string constant whose opening quote has already
+// been read. It keeps scanning up to the closing quote after a bad escape
+// sequence, so that only the literal itself is reported.
+func (l *Lexer) lexString(start entity.Position) (string, error) {
+	var lit strings.Builder
+	var failure error
+	for {
+		r, _, err := l.reader.ReadRune()
+		if err != nil || r == '\n' {
+			if err == nil {
+				l.nextLine()
+			}
+
+			return lit.String(), fmt.Errorf("unterminated string literal")
+		}
+
+		l.pos.Column++
+		switch r {
+		case '"':
+			return lit.String(), failure
+		case '\\':
+			escaped, err := l.lexEscape()
+			if err != nil && failure == nil {
+				failure = err
+			}
 
+			lit.WriteString(escaped)
+		default:
+			lit.WriteRune(r)
+		}
+	}
+}
+
+func (l *Lexer) lexEscape() (string, error) {
 	r, _, err := l.reader.ReadRune()
 	if err != nil {
-		if err == io.EOF {
-			return lit
-		}
+		return "", fmt.Errorf("unterminated string literal")
 	}
 
 	l.pos.Column++
-	if r == '!' || r == '>' || r == '<' || r == '-' {
-		lit = lit + string(r)
-		r, _, err = l.reader.ReadRune()
-		if err != nil {
-			if err == io.EOF {
-				return lit
+	switch r {
+	case '"', '\\', '/':
+		return string(r), nil
+	case 'n':
+		return "\n", nil
+	case 't':
+		return "\t", nil
+	case 'r':
+		return "\r", nil
+	case 'u':
+		code := ""
+		for len(code) < 4 {
+			digit, _, err := l.reader.ReadRune()
+			if err != nil {
+				return "", fmt.Errorf("unterminated string literal")
 			}
-		}
 
-		if !unicode.IsSpace(r) {
-			lit = lit + string(r)
+			l.pos.Column++
+			if !strings.ContainsRune("0123456789abcdefABCDEF", digit) {
+				l.backup()
+				return "", fmt.Errorf("invalid unicode escape \\u%s", code)
+			}
+
+			code += string(digit)
 		}
 
-		return lit
-	} else {
-		l.backup()
+		value, _ := strconv.ParseUint(code, 16, 32)
+		return string(rune(value)), nil
+	default:
+		return "", fmt.Errorf("unknown escape sequence \\%c", r)
+	}
+}
+
+func (l *Lexer) lexSym(r rune) string {
+	next, _, err := l.reader.ReadRune()
+	if err != nil {
+		return string(r)
+	}
+
+	l.pos.Column++
+	lit := string(r) + string(next)
+	if lit == "!=" || lit == ">=" || lit == "<=" || lit == "->" {
 		return lit
 	}
+
+	l.backup()
+	return string(r)
 }
@@ -0,0 +1,229 @@
+package model
+
+import (
+	"alpha-executor/entity"
+	"bufio"
+	"encoding/json"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func lex(source string) ([]LexType, []string) {
+	lexer := NewLexer(bufio.NewReader(strings.NewReader(source)))
+	types := make([]LexType, 0)
+	for _, statement := range lexer.Lex() {
+		for _, token := range statement {
+			types = append(types, token.Type)
+		}
+	}
+
+	messages := make([]string, 0)
+	for _, err := range lexer.Errors() {
+		messages = append(messages, err.Message)
+	}
+
+	return types, messages
+}
+
+func TestLexNamesWithDashesAndSlashes(t *testing.T) {
+	tests := []struct {
+		source   string
+		expected []LexType
+	}{
+		{"RANGE ВРАЧ-ПАЦИЕНТ Y", []LexType{RANGE, FREE_RELATION, FREE_RELATION}},
+		{"Y.К/В = 999", []LexType{ATTRIBUTE, EQUALS, INTEGER}},
+		{"EMP.salary-EMP.id", []LexType{ILLEGAL}},
+		{"EMP.salary - EMP.id / 2", []LexType{ATTRIBUTE, MINUS, ATTRIBUTE, DIVIDE, INTEGER}},
+		{"EMP.salary -EMP.id", []LexType{ATTRIBUTE, MINUS, ATTRIBUTE}},
+		{"4-2/1", []LexType{INTEGER, MINUS, INTEGER, DIVIDE, INTEGER}},
+	}
+
+	for _, test := range tests {
+		types, _ := lex(test.source)
+		if !slices.Equal(types, test.expected) {
+			t.Errorf("%s: got %v, want %v", test.source, types, test.expected)
+		}
+	}
+}
+
+func TestLexSampleProgram(t *testing.T) {
+	file, err := os.Open("../input.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	lexer := NewLexer(bufio.NewReader(file))
+	names := make([]string, 0)
+	for _, statement := range lexer.Lex() {
+		for _, token := range statement {
+			if token.Type == FREE_RELATION || token.Type == ATTRIBUTE {
+				names = append(names, token.Value)
+			}
+		}
+	}
+
+	if errors := lexer.Errors(); len(errors) > 0 {
+		t.Fatalf("got errors %v", errors)
+	}
+
+	for _, name := range []string{"ВРАЧ-ПАЦИЕНТ", "ВРАЧ.К/В", "Y.Р/Н", "X.Р/Н"} {
+		if !slices.Contains(names, name) {
+			t.Errorf("%s is not lexed as one name in %v", name, names)
+		}
+	}
+}
+
+func TestLexMalformedIdentifier(t *testing.T) {
+	_, errors := lex("GET W (EMP.a.b)")
+	if len(errors) != 1 || !strings.Contains(errors[0], "malformed identifier") {
+		t.Errorf("got %v, want a malformed identifier", errors)
+	}
+}
+
+func TestLexStringConstants(t *testing.T) {
+	tests := []struct {
+		source   string
+		expected string
+	}{
+		{`"Ivanov Ivan"`, "Ivanov Ivan"},
+		{`"O'Brien, J."`, "O'Brien, J."},
+		{`"Пётр Ильич"`, "Пётр Ильич"},
+		{`"say \"hi\""`, `say "hi"`},
+		{`"a\\b\/c"`, `a\b/c`},
+		{`"tab\there\r\nnext"`, "tab\there\r\nnext"},
+		{`"\u0416ук"`, "Жук"},
+		{`""`, ""},
+	}
+
+	for _, test := range tests {
+		lexer := NewLexer(bufio.NewReader(strings.NewReader(test.source)))
+		output := lexer.Lex()
+		if len(lexer.Errors()) > 0 || len(output) != 1 || len(output[0]) != 1 {
+			t.Errorf("%s: got %v %v, want one constant", test.source, output, lexer.Errors())
+			continue
+		}
+
+		token := output[0][0]
+		if token.Type != CONSTANT || token.Value != test.expected {
+			t.Errorf("%s: got %s %q, want CONSTANT %q", test.source, token.Type, token.Value, test.expected)
+		}
+	}
+}
+
+func TestLexStringErrors(t *testing.T) {
+	tests := []struct {
+		source  string
+		message string
+		column  int
+	}{
+		{`EMP.name = "ann`, "unterminated string literal", 12},
+		{"EMP.name = \"ann\nGET W (EMP)", "unterminated string literal", 12},
+		{`EMP.name = "a\qb"`, `unknown escape sequence \q`, 12},
+		{`EMP.name = "\u04G6"`, `invalid unicode escape \u04`, 12},
+	}
+
+	for _, test := range tests {
+		lexer := NewLexer(bufio.NewReader(strings.NewReader(test.source)))
+		lexer.Lex()
+		errors := lexer.Errors()
+		if len(errors) != 1 || errors[0].Message != test.message || errors[0].Position.Line != 1 || errors[0].Position.Column != test.column {
+			t.Errorf("%q: got %v, want %q at 1:%d", test.source, errors, test.message, test.column)
+		}
+	}
+}
+
+func TestLexAfterUnterminatedString(t *testing.T) {
+	types, errors := lex("EMP.name = \"ann\nGET W (EMP)")
+	expected := []LexType{ATTRIBUTE, EQUALS, ILLEGAL, GET, FREE_RELATION, LEFT_PARENTHESIS, FREE_RELATION, RIGHT_PARENTHESIS}
+	if len(errors) != 1 || !slices.Equal(types, expected) {
+		t.Errorf("got %v %v, want %v", types, errors, expected)
+	}
+}
+
+func TestLexComments(t *testing.T) {
+	tests := []struct {
+		source   string
+		expected []LexType
+		errors   int
+	}{
+		{"GET W (EMP) -- the whole relation", []LexType{GET, FREE_RELATION, LEFT_PARENTHESIS, FREE_RELATION, RIGHT_PARENTHESIS}, 0},
+		{"-- GET W (EMP)\nGET V", []LexType{GET, FREE_RELATION}, 0},
+		{"EMP.a -> EMP.b", []LexType{ATTRIBUTE, IMPLICATION, ATTRIBUTE}, 0},
+		{"GET /* a\nb */ W", []LexType{GET, FREE_RELATION}, 0},
+		{"4 / 2 /* half */", []LexType{INTEGER, DIVIDE, INTEGER}, 0},
+		{"GET W /* open", []LexType{GET, FREE_RELATION, ILLEGAL}, 1},
+	}
+
+	for _, test := range tests {
+		types, errors := lex(test.source)
+		if !slices.Equal(types, test.expected) || len(errors) != test.errors {
+			t.Errorf("%s: got %v %v, want %v", test.source, types, errors, test.expected)
+		}
+	}
+}
+
+func TestLexCommentsKeepPositions(t *testing.T) {
+	lexer := NewLexer(bufio.NewReader(strings.NewReader("-- note\nGET /* a\nlonger */ W /* x */ (EMP)")))
+	output := lexer.Lex()
+	expected := []entity.Position{{Line: 2, Column: 1}, {Line: 3, Column: 11}, {Line: 3, Column: 21}, {Line: 3, Column: 22}}
+	if len(output) != 1 || len(output[0]) < len(expected) {
+		t.Fatalf("got %v", output)
+	}
+
+	for i, position := range expected {
+		if output[0][i].Position != position {
+			t.Errorf("%s: got %v, want %v", output[0][i].Value, output[0][i].Position, position)
+		}
+	}
+}
+
+func TestLexBlockComments(t *testing.T) {
+	tests := []struct {
+		source   string
+		expected []LexType
+		errors   int
+	}{
+		{"GET /* comment */ W", []LexType{GET, FREE_RELATION}, 0},
+		{"/*/ GET W (EMP); */ GET V", []LexType{GET, FREE_RELATION}, 0},
+		{"/**/ GET", []LexType{GET}, 0},
+		{"GET /*/", []LexType{GET, ILLEGAL}, 1},
+	}
+
+	for _, test := range tests {
+		types, errors := lex(test.source)
+		if !slices.Equal(types, test.expected) || len(errors) != test.errors {
+			t.Errorf("%s: got %v %v, want %v", test.source, types, errors, test.expected)
+		}
+	}
+}
+
+func TestTokensJSON(t *testing.T) {
+	lexer := NewLexer(bufio.NewReader(strings.NewReader("GET W (EMP);\n\"a")))
+	statements, err := json.Marshal(lexer.Lex())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `[[{"type":"GET","value":"GET","position":{"line":1,"column":1}},` +
+		`{"type":"FREE_RELATION","value":"W","position":{"line":1,"column":5}},` +
+		`{"type":"(","value":"(","position":{"line":1,"column":7}},` +
+		`{"type":"FREE_RELATION","value":"EMP","position":{"line":1,"column":8}},` +
+		`{"type":")","value":")","position":{"line":1,"column":11}}],` +
+		`[{"type":"ILLEGAL","value":"a","position":{"line":2,"column":1}}]]`
+	if string(statements) != expected {
+		t.Errorf("got %s, want %s", statements, expected)
+	}
+
+	errors, err := json.Marshal(lexer.Errors())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected = `[{"type":"Compilation Error","message":"unterminated string literal","position":{"line":2,"column":1}}]`
+	if string(errors) != expected {
+		t.Errorf("got %s, want %s", errors, expected)
+	}
+}
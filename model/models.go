@@ -6,13 +6,43 @@ type (
 	TestingReceiver struct {
 		Query     string           `json:"query"`
 		Relations entity.Relations `json:"relations"`
+		Schemas   entity.Schemas   `json:"schemas,omitempty"`
+		Language  string           `json:"language,omitempty"`
 	}
 
 	TestingSender struct {
-		Results *entity.Relations `json:"results"`
+		Results *entity.Workspaces `json:"results"`
 	}
 
 	ValidationReceiver struct {
+		Query    string `json:"query"`
+		Language string `json:"language,omitempty"`
+	}
+
+	SourceReceiver struct {
 		Query string `json:"query"`
 	}
+
+	FormattingSender struct {
+		Query string `json:"query"`
+	}
+
+	TokensSender struct {
+		Statements [][]*Token            `json:"statements"`
+		Errors     []*entity.CustomError `json:"errors"`
+	}
+
+	AlgebraSender struct {
+		Expressions []string           `json:"expressions"`
+		Results     *entity.Workspaces `json:"results"`
+	}
+
+	SQLSender struct {
+		SQL string `json:"sql"`
+	}
+
+	ASTSender struct {
+		Program any                   `json:"program"`
+		Errors  []*entity.CustomError `json:"errors"`
+	}
 )
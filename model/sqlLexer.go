@@ -0,0 +1,356 @@
+package model
+
+import (
+	"alpha-executor/entity"
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+var sqlKeywords = map[string]LexType{
+	"SELECT":   SELECT,
+	"FROM":     FROM,
+	"WHERE":    WHERE,
+	"GROUP":    GROUP,
+	"ORDER":    ORDER,
+	"BY":       BY,
+	"ASC":      UP,
+	"DESC":     DOWN,
+	"LIMIT":    LIMIT,
+	"DISTINCT": DISTINCT,
+	"AS":       AS,
+	"CREATE":   CREATE,
+	"VIEW":     VIEW,
+	"EXISTS":   EXISTS,
+	"NOT":      NOT,
+	"AND":      AND,
+	"OR":       DISJUNCTION,
+	"IN":       IN,
+	"BETWEEN":  BETWEEN,
+	"LIKE":     LIKE,
+	"IS":       IS,
+	"NULL":     NULL,
+	"COUNT":    COUNT,
+	"SUM":      TOTAL,
+	"MAX":      MAX,
+	"MIN":      MIN,
+	"AVG":      AVG,
+}
+
+// SQLLexer splits SQL statements into tokens of the same types as Lexer
+// where the languages agree: OR is a DISJUNCTION, ASC and DESC are UP and
+// DOWN, SUM is TOTAL and <> is !=. Keywords are case-insensitive, strings
+// are quoted by ' and names may be quoted by ".
+type SQLLexer struct {
+	pos     entity.Position
+	reader  *bufio.Reader
+	results [][]*Token
+	errors  []*entity.CustomError
+}
+
+func NewSQLLexer(reader *bufio.Reader) *SQLLexer {
+	return &SQLLexer{
+		pos:     entity.Position{Line: 1, Column: 0},
+		reader:  reader,
+		results: make([][]*Token, 0),
+		errors:  make([]*entity.CustomError, 0),
+	}
+}
+
+// Errors returns the lexical errors found by Lex.
+func (l *SQLLexer) Errors() []*entity.CustomError {
+	return l.errors
+}
+
+func (l *SQLLexer) Lex() [][]*Token {
+	result := make([]*Token, 0)
+	for {
+		r, _, err := l.reader.ReadRune()
+		if err != nil {
+			if err == io.EOF {
+				l.results = append(l.results, result)
+				return l.results
+			}
+
+			panic(err)
+		}
+
+		l.pos.Column++
+		start := l.pos
+
+		switch r {
+		case ';':
+			l.results = append(l.results, result)
+			result = make([]*Token, 0)
+		case '\n':
+			l.nextLine()
+		case '=':
+			result = append(result, &Token{EQUALS, string(r), start})
+		case '+':
+			result = append(result, &Token{PLUS, string(r), start})
+		case '*':
+			result = append(result, &Token{MULTIPLY, string(r), start})
+		case '(':
+			result = append(result, &Token{LEFT_PARENTHESIS, string(r), start})
+		case ')':
+			result = append(result, &Token{RIGHT_PARENTHESIS, string(r), start})
+		case ',':
+			result = append(result, &Token{COMMA, string(r), start})
+		case '-':
+			if l.nextIs('-') {
+				l.skipLineComment()
+				break
+			}
+
+			result = append(result, &Token{MINUS, string(r), start})
+		case '/':
+			if l.nextIs('*') {
+				if !l.skipBlockComment() {
+					result = l.illegal(result, "/*", start, "unterminated block comment")
+				}
+				break
+			}
+
+			result = append(result, &Token{DIVIDE, string(r), start})
+		case '<', '>', '!':
+			result = l.lexComparison(result, r, start)
+		case '\'':
+			lit, terminated := l.lexQuoted('\'')
+			if !terminated {
+				result = l.illegal(result, lit, start, "unterminated string literal")
+				break
+			}
+
+			if _, isDate := entity.ParseTemporal(lit); isDate {
+				result = append(result, &Token{DATE, lit, start})
+				break
+			}
+
+			result = append(result, &Token{CONSTANT, lit, start})
+		default:
+			if unicode.IsSpace(r) {
+				continue
+			}
+
+			if unicode.IsDigit(r) {
+				result = append(result, l.lexNumber(r, start))
+				break
+			}
+
+			if unicode.IsLetter(r) || r == '_' || r == '"' {
+				result = l.lexName(result, r, start)
+				break
+			}
+
+			result = l.illegal(result, string(r), start, fmt.Sprintf("illegal character %q", r))
+		}
+	}
+}
+
+func (l *SQLLexer) lexComparison(result []*Token, r rune, start entity.Position) []*Token {
+	lit := string(r)
+	next := l.peek()
+	if next == '=' || r == '<' && next == '>' {
+		l.read()
+		lit += string(next)
+	}
+
+	switch lit {
+	case "<":
+		return append(result, &Token{LESS_THAN, lit, start})
+	case "<=":
+		return append(result, &Token{LESS_THAN_EQUALS, lit, start})
+	case ">":
+		return append(result, &Token{GREATER_THAN, lit, start})
+	case ">=":
+		return append(result, &Token{GREATER_THAN_EQUALS, lit, start})
+	case "<>", "!=":
+		return append(result, &Token{NOT_EQUALS, lit, start})
+	default:
+		return l.illegal(result, lit, start, fmt.Sprintf("illegal character %q", r))
+	}
+}
+
+func (l *SQLLexer) lexNumber(first rune, start entity.Position) *Token {
+	lit := string(first)
+	lexType := INTEGER
+	for {
+		next, _ := l.reader.Peek(2)
+		if len(next) == 0 {
+			break
+		}
+
+		fraction := next[0] == '.' && len(next) > 1 && isDigit(next[1]) && lexType == INTEGER
+		if !isDigit(next[0]) && !fraction {
+			break
+		}
+
+		if fraction {
+			lexType = FLOAT
+		}
+
+		lit += string(l.read())
+	}
+
+	return &Token{lexType, lit, start}
+}
+
+// lexName scans a keyword, a name or a name qualified by a table, t.a or t.*,
+// each part of which may be quoted.
+func (l *SQLLexer) lexName(result []*Token, first rune, start entity.Position) []*Token {
+	parts := make([]string, 0, 2)
+	quoted := false
+	for r := first; ; r = l.read() {
+		part := string(r)
+		if r == '"' {
+			terminated := false
+			quoted = true
+			if part, terminated = l.lexQuoted('"'); !terminated {
+				return l.illegal(result, part, start, "unterminated quoted name")
+			}
+		} else if r != '*' {
+			for next := l.peek(); unicode.IsLetter(next) || unicode.IsDigit(next) || next == '_'; next = l.peek() {
+				part += string(l.read())
+			}
+		}
+
+		parts = append(parts, part)
+		if r == '*' || l.peek() != '.' {
+			break
+		}
+
+		l.read()
+		if next := l.peek(); next != '"' && next != '*' && next != '_' && !unicode.IsLetter(next) {
+			lit := strings.Join(parts, ".") + "."
+			return l.illegal(result, lit, start, fmt.Sprintf("malformed name %q", lit))
+		}
+	}
+
+	lit := strings.Join(parts, ".")
+	switch {
+	case len(parts) > 2 || strings.Count(lit, ".") != len(parts)-1:
+		return l.illegal(result, lit, start, fmt.Sprintf("malformed name %q", lit))
+	case len(parts) == 2:
+		return append(result, &Token{ATTRIBUTE, lit, start})
+	}
+
+	if lexType, isKeyword := sqlKeywords[strings.ToUpper(lit)]; isKeyword && !quoted {
+		return append(result, &Token{lexType, lit, start})
+	}
+
+	return append(result, &Token{FREE_RELATION, lit, start})
+}
+
+// lexQuoted scans a string or a name up to the closing quote, a doubled
+// quote standing for the quote itself, and reports whether it was closed.
+func (l *SQLLexer) lexQuoted(quote rune) (string, bool) {
+	var lit strings.Builder
+	for {
+		r, _, err := l.reader.ReadRune()
+		if err != nil {
+			return lit.String(), false
+		}
+
+		if r == '\n' {
+			l.nextLine()
+		} else {
+			l.pos.Column++
+		}
+
+		if r == quote {
+			if l.peek() != quote {
+				return lit.String(), true
+			}
+
+			l.read()
+		}
+
+		lit.WriteRune(r)
+	}
+}
+
+func (l *SQLLexer) nextLine() {
+	l.pos.Line++
+	l.pos.Column = 0
+}
+
+// peek returns the next rune without reading it, or 0 at the end.
+func (l *SQLLexer) peek() rune {
+	r, _, err := l.reader.ReadRune()
+	if err != nil {
+		return 0
+	}
+
+	if err = l.reader.UnreadRune(); err != nil {
+		panic(err)
+	}
+
+	return r
+}
+
+func (l *SQLLexer) nextIs(r rune) bool {
+	return l.peek() == r
+}
+
+func (l *SQLLexer) read() rune {
+	r, _, err := l.reader.ReadRune()
+	if err != nil {
+		return 0
+	}
+
+	l.pos.Column++
+	return r
+}
+
+func (l *SQLLexer) skipLineComment() {
+	for {
+		r, _, err := l.reader.ReadRune()
+		if err != nil {
+			return
+		}
+
+		if r == '\n' {
+			l.nextLine()
+			return
+		}
+
+		l.pos.Column++
+	}
+}
+
+// skipBlockComment skips a comment up to */, starting at the * of /*, which
+// therefore cannot close the comment itself.
+func (l *SQLLexer) skipBlockComment() bool {
+	l.read()
+	previous := rune(0)
+	for {
+		r, _, err := l.reader.ReadRune()
+		if err != nil {
+			return false
+		}
+
+		if r == '\n' {
+			l.nextLine()
+		} else {
+			l.pos.Column++
+		}
+
+		if r == '/' && previous == '*' {
+			return true
+		}
+
+		previous = r
+	}
+}
+
+func (l *SQLLexer) illegal(result []*Token, lit string, position entity.Position, message string) []*Token {
+	l.errors = append(l.errors, &entity.CustomError{
+		ErrorType: entity.ResponseTypes["CE"],
+		Message:   message,
+		Position:  position,
+	})
+
+	return append(result, &Token{ILLEGAL, lit, position})
+}
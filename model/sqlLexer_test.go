@@ -0,0 +1,22 @@
+package model
+
+import (
+	"bufio"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSQLLexBlockComments(t *testing.T) {
+	lexer := NewSQLLexer(bufio.NewReader(strings.NewReader("/*/ SELECT a; */ SELECT b")))
+	types := make([]LexType, 0)
+	for _, statement := range lexer.Lex() {
+		for _, token := range statement {
+			types = append(types, token.Type)
+		}
+	}
+
+	if expected := []LexType{SELECT, FREE_RELATION}; !slices.Equal(types, expected) || len(lexer.Errors()) > 0 {
+		t.Errorf("got %v %v, want %v", types, lexer.Errors(), expected)
+	}
+}
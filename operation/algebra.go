@@ -0,0 +1,161 @@
+package operation
+
+import (
+	"alpha-executor/entity"
+	"fmt"
+	"strings"
+)
+
+// AlgebraExpression is a node of a relational algebra expression tree.
+// Attributes inside a tree are qualified by the variable they come from,
+// e.g. X.name, and X.* stands for all attributes of X.
+type AlgebraExpression interface {
+	String() string
+}
+
+// AlgebraStatement assigns the value of an algebra expression to a workspace.
+// A RANGE statement only names the relation its variable ranges over.
+type AlgebraStatement struct {
+	kind       string
+	workspace  string
+	expression AlgebraExpression
+}
+
+func (a *AlgebraStatement) GetKind() string {
+	return a.kind
+}
+
+func (a *AlgebraStatement) Workspace() string {
+	return a.workspace
+}
+
+func (a *AlgebraStatement) String() string {
+	return fmt.Sprintf("%s := %s", a.workspace, a.expression)
+}
+
+type RelationNode struct {
+	name     string
+	position entity.Position
+}
+
+func (r *RelationNode) String() string {
+	return r.name
+}
+
+// RenameNode qualifies every attribute of its operand by variable.
+type RenameNode struct {
+	variable string
+	child    AlgebraExpression
+}
+
+func (r *RenameNode) String() string {
+	return fmt.Sprintf("ρ[%s](%s)", r.variable, r.child)
+}
+
+// RenameAttributesNode renames attributes of its operand, keeping their
+// variables.
+type RenameAttributesNode struct {
+	renames  []entity.Pair[string, string]
+	child    AlgebraExpression
+	position entity.Position
+}
+
+func (r *RenameAttributesNode) String() string {
+	renames := make([]string, 0, len(r.renames))
+	for _, rename := range r.renames {
+		renames = append(renames, fmt.Sprintf("%s → %s", rename.Left, rename.Right))
+	}
+
+	return fmt.Sprintf("ρ[%s](%s)", strings.Join(renames, ", "), r.child)
+}
+
+type ProductNode struct {
+	left     AlgebraExpression
+	right    AlgebraExpression
+	position entity.Position
+}
+
+func (p *ProductNode) String() string {
+	left := p.left.String()
+	if _, isProduct := p.left.(*ProductNode); !isProduct {
+		left = operandString(p.left)
+	}
+
+	return fmt.Sprintf("%s × %s", left, operandString(p.right))
+}
+
+// SelectionNode keeps the tuples for which condition, an ALPHA
+// qualification without quantifiers at its top, is TRUE. If unqualified is
+// set, the condition may name an attribute without its variable.
+type SelectionNode struct {
+	condition   Expression
+	child       AlgebraExpression
+	unqualified bool
+}
+
+func (s *SelectionNode) String() string {
+	printer := NewPrinter()
+	return fmt.Sprintf("σ[%s](%s)", printer.PrintExpression(s.condition), s.child)
+}
+
+type ProjectionNode struct {
+	attributes []string
+	child      AlgebraExpression
+	position   entity.Position
+}
+
+func (p *ProjectionNode) String() string {
+	return fmt.Sprintf("π[%s](%s)", strings.Join(p.attributes, ", "), p.child)
+}
+
+type DivisionNode struct {
+	left     AlgebraExpression
+	right    AlgebraExpression
+	position entity.Position
+}
+
+func (d *DivisionNode) String() string {
+	return fmt.Sprintf("%s ÷ %s", operandString(d.left), operandString(d.right))
+}
+
+// JoinNode is the natural join of its operands on the attributes of the
+// same name, or the θ-join σ[condition](left × right) if it has a condition.
+type JoinNode struct {
+	left      AlgebraExpression
+	right     AlgebraExpression
+	condition Expression
+	position  entity.Position
+}
+
+func (j *JoinNode) String() string {
+	if j.condition == nil {
+		return fmt.Sprintf("%s ⋈ %s", operandString(j.left), operandString(j.right))
+	}
+
+	printer := NewPrinter()
+	return fmt.Sprintf("%s ⋈[%s] %s", operandString(j.left), printer.PrintExpression(j.condition), operandString(j.right))
+}
+
+// SetOperationNode is a union, intersection or difference; the attributes of
+// the right operand are matched to those of the left one by name.
+type SetOperationNode struct {
+	kind     string
+	left     AlgebraExpression
+	right    AlgebraExpression
+	position entity.Position
+}
+
+func (s *SetOperationNode) String() string {
+	return fmt.Sprintf("%s %s %s", operandString(s.left), s.kind, operandString(s.right))
+}
+
+// operandString parenthesises the operands of binary operators that are
+// binary operations themselves; unary operators carry their own brackets.
+func operandString(expression AlgebraExpression) string {
+	switch expression.(type) {
+	case *ProductNode, *DivisionNode, *JoinNode, *SetOperationNode:
+		return fmt.Sprintf("(%s)", expression)
+	default:
+		return expression.String()
+	}
+}
@@ -0,0 +1,613 @@
+package operation
+
+import (
+	"alpha-executor/entity"
+	"alpha-executor/model"
+	"alpha-executor/repository"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// AlgebraExecutor evaluates relational algebra expression trees with the
+// relational operators of this package. Selections bind the tuple of every
+// variable in the repository, so that conditions are evaluated exactly as the
+// interpreter evaluates qualifications.
+type AlgebraExecutor struct {
+	repository  *repository.AlphaRepository
+	interpreter *Interpreter
+}
+
+func NewAlgebraExecutor(repository *repository.AlphaRepository) *AlgebraExecutor {
+	return &AlgebraExecutor{
+		repository:  repository,
+		interpreter: NewInterpreter(repository),
+	}
+}
+
+// Execute runs a statement; the result of a GET is stored as a workspace,
+// with attributes no longer qualified by their variables.
+func (a *AlgebraExecutor) Execute(statement *AlgebraStatement) error {
+	relation, err := a.Evaluate(statement.expression)
+	if err != nil {
+		return err
+	}
+
+	if statement.kind == model.RANGE.String() {
+		a.repository.AddRelation(statement.workspace, relation)
+		return nil
+	}
+
+	result := distinct(relation, unqualify)
+
+	a.repository.AddGetRelation(statement.workspace, entity.NewWorkspace(result, nil))
+	a.repository.AddRelation(statement.workspace, result)
+	return nil
+}
+
+func (a *AlgebraExecutor) Evaluate(expression AlgebraExpression) (*entity.Relation, error) {
+	switch node := expression.(type) {
+	case *RelationNode:
+		relation, err := a.repository.GetRelation(node.name)
+		if err != nil {
+			err.(*entity.CustomError).Position = node.position
+			return nil, err
+		}
+
+		return relation, nil
+	case *RenameNode:
+		relation, err := a.Evaluate(node.child)
+		if err != nil {
+			return nil, err
+		}
+
+		renamed := make(entity.Relation, len(*relation))
+		for row := range *relation {
+			renamed[qualify(unqualify(row), node.variable)] = struct{}{}
+		}
+
+		return &renamed, nil
+	case *RenameAttributesNode:
+		relation, err := a.Evaluate(node.child)
+		if err != nil {
+			return nil, err
+		}
+
+		return a.renameAttributes(relation, node.renames, node.position)
+	case *ProductNode:
+		left, right, err := a.evaluateOperands(node.left, node.right)
+		if err != nil {
+			return nil, err
+		}
+
+		return a.product(left, right, node.position)
+	case *SelectionNode:
+		relation, err := a.Evaluate(node.child)
+		if err != nil {
+			return nil, err
+		}
+
+		condition := node.condition
+		if node.unqualified {
+			if condition, err = qualifyNames(condition, attributes(relation)); err != nil {
+				return nil, err
+			}
+		}
+
+		return a.selection(relation, condition)
+	case *ProjectionNode:
+		relation, err := a.Evaluate(node.child)
+		if err != nil {
+			return nil, err
+		}
+
+		keys := attributes(relation)
+		projected := expand(relation, node.attributes)
+		for i, attribute := range projected {
+			if key, found, err := resolve(keys, attribute, node.position); err != nil {
+				return nil, err
+			} else if found {
+				projected[i] = key
+			}
+		}
+
+		projection := Projection{}
+		relationPair := entity.Pair[string, *entity.Relation]{Right: relation}
+		return projection.Execute(relationPair, projected, node.position)
+	case *DivisionNode:
+		left, right, err := a.evaluateOperands(node.left, node.right)
+		if err != nil {
+			return nil, err
+		}
+
+		division := Division{}
+		if len(*right) == 0 {
+			// An empty divisor has no rows to tell its attributes; they
+			// follow from the expression instead, if they do.
+			var divisorAttributes []string
+			if names, known := heading(node.right); known {
+				divisorAttributes = matchHeading(names, left)
+			}
+
+			return division.ExecuteOver(left, right, divisorAttributes, node.position)
+		}
+
+		return division.Execute(left, renameKeys(right, match(right, left)), node.position)
+	case *JoinNode:
+		left, right, err := a.evaluateOperands(node.left, node.right)
+		if err != nil {
+			return nil, err
+		}
+
+		if node.condition != nil {
+			product, err := a.product(left, right, node.position)
+			if err != nil {
+				return nil, err
+			}
+
+			condition, err := qualifyNames(node.condition, attributes(product))
+			if err != nil {
+				return nil, err
+			}
+
+			return a.selection(product, condition)
+		}
+
+		right = renameKeys(right, match(right, left))
+		common := make([]string, 0)
+		leftKeys := attributes(left)
+		for _, key := range attributes(right) {
+			if slices.Contains(leftKeys, key) {
+				common = append(common, key)
+			}
+		}
+
+		join := Join{}
+		return join.Execute(entity.Pair[string, *entity.Relation]{Right: left}, entity.Pair[string, *entity.Relation]{Right: right}, common)
+	case *SetOperationNode:
+		left, right, err := a.evaluateOperands(node.left, node.right)
+		if err != nil {
+			return nil, err
+		}
+
+		right = renameKeys(right, match(right, left))
+		switch node.kind {
+		case model.UNION.String():
+			union := Union{}
+			relation, err := union.Execute(left, right, node.position)
+			if err != nil {
+				return nil, err
+			}
+
+			return distinct(relation, func(row *entity.RowMap) *entity.RowMap { return row }), nil
+		case model.INTERSECTION.String():
+			intersection := Intersection{}
+			return intersection.Execute(left, right, node.position)
+		default:
+			difference := Difference{}
+			return difference.Execute(left, right, node.position)
+		}
+	default:
+		return nil, &entity.CustomError{
+			ErrorType: entity.ResponseTypes["RT"],
+			Message:   fmt.Sprintf("Unknown algebra expression %s", expression),
+		}
+	}
+}
+
+func (a *AlgebraExecutor) evaluateOperands(left, right AlgebraExpression) (*entity.Relation, *entity.Relation, error) {
+	leftRelation, err := a.Evaluate(left)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rightRelation, err := a.Evaluate(right)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return leftRelation, rightRelation, nil
+}
+
+func (a *AlgebraExecutor) selection(relation *entity.Relation, condition Expression) (*entity.Relation, error) {
+	selected := make(entity.Relation)
+	for row := range *relation {
+		for variable, variableRow := range split(row) {
+			a.repository.AddRow(variable, variableRow)
+		}
+
+		truth, err := a.interpreter.evaluateCondition(condition)
+		if err != nil {
+			return nil, err
+		}
+
+		if truth == TRUE {
+			selected[row] = struct{}{}
+		}
+	}
+
+	return &selected, nil
+}
+
+// product refuses operands sharing an attribute, whose values the product
+// would mix up; one of them has to be renamed by ρ first.
+func (a *AlgebraExecutor) product(left, right *entity.Relation, position entity.Position) (*entity.Relation, error) {
+	leftKeys := attributes(left)
+	for _, key := range attributes(right) {
+		if slices.Contains(leftKeys, key) {
+			return nil, &entity.CustomError{
+				ErrorType: entity.ResponseTypes["RT"],
+				Message:   fmt.Sprintf("attribute %s is in both operands of a product", key),
+				Position:  position,
+			}
+		}
+	}
+
+	product := Product{}
+	return product.Execute(left, right), nil
+}
+
+// renameAttributes renames attributes of the rows of relation; an empty
+// relation has none to rename.
+func (a *AlgebraExecutor) renameAttributes(relation *entity.Relation, renames []entity.Pair[string, string], position entity.Position) (*entity.Relation, error) {
+	if len(*relation) == 0 {
+		return relation, nil
+	}
+
+	keys := attributes(relation)
+	mapping := make(map[string]string, len(renames))
+	for _, pair := range renames {
+		key, found, err := resolve(keys, pair.Left, position)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			return nil, &entity.CustomError{
+				ErrorType: entity.ResponseTypes["CE"],
+				Message:   fmt.Sprintf("attribute %s doesn't exist", pair.Left),
+				Position:  position,
+			}
+		}
+
+		variable, _, isQualified := strings.Cut(key, ".")
+		mapping[key] = pair.Right
+		if isQualified {
+			mapping[key] = variable + "." + pair.Right
+		}
+	}
+
+	return renameKeys(relation, mapping), nil
+}
+
+// attributes lists the attributes of the rows of a relation.
+func attributes(relation *entity.Relation) []string {
+	keys := make([]string, 0)
+	for row := range *relation {
+		for key := range *row {
+			if !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	slices.Sort(keys)
+	return keys
+}
+
+// resolve finds the attribute a name refers to: the attribute of that name,
+// or else the only one of that name qualified by a variable.
+func resolve(keys []string, name string, position entity.Position) (string, bool, error) {
+	if slices.Contains(keys, name) {
+		return name, true, nil
+	}
+
+	found := ""
+	for _, key := range keys {
+		if _, attribute, isQualified := strings.Cut(key, "."); isQualified && attribute == name {
+			if found != "" {
+				return "", false, &entity.CustomError{
+					ErrorType: entity.ResponseTypes["CE"],
+					Message:   fmt.Sprintf("attribute %s is ambiguous: %s or %s", name, found, key),
+					Position:  position,
+				}
+			}
+
+			found = key
+		}
+	}
+
+	return found, found != "", nil
+}
+
+// match maps the attributes of relation to the attributes of target with the
+// same name, whatever variables qualify them.
+func match(relation, target *entity.Relation) map[string]string {
+	keys, targetKeys := attributes(relation), attributes(target)
+	mapping := make(map[string]string)
+	for _, key := range keys {
+		if slices.Contains(targetKeys, key) {
+			continue
+		}
+
+		_, name, _ := strings.Cut(key, ".")
+		candidates := make([]string, 0, 1)
+		for _, targetKey := range targetKeys {
+			if _, targetName, _ := strings.Cut(targetKey, "."); targetName == name && !slices.Contains(keys, targetKey) {
+				candidates = append(candidates, targetKey)
+			}
+		}
+
+		if len(candidates) == 1 {
+			mapping[key] = candidates[0]
+		}
+	}
+
+	return mapping
+}
+
+// heading returns the attributes of the result of an expression as far as
+// the expression alone tells them: the names of a projection, or X.* for
+// the attributes of variable X, whatever they are.
+func heading(expression AlgebraExpression) ([]string, bool) {
+	switch node := expression.(type) {
+	case *ProjectionNode:
+		return node.attributes, true
+	case *RenameNode:
+		names, known := heading(node.child)
+		if !known {
+			return []string{node.variable + ".*"}, true
+		}
+
+		qualified := make([]string, 0, len(names))
+		for _, name := range names {
+			qualified = append(qualified, node.variable+"."+attributeName(name))
+		}
+
+		return qualified, true
+	case *RenameAttributesNode:
+		names, known := heading(node.child)
+		renamed := make([]string, 0, len(names))
+		for _, name := range names {
+			for _, rename := range node.renames {
+				if attributeName(rename.Left) == attributeName(name) {
+					name = strings.TrimSuffix(name, attributeName(name)) + rename.Right
+				}
+			}
+
+			renamed = append(renamed, name)
+		}
+
+		return renamed, known
+	case *SelectionNode:
+		return heading(node.child)
+	case *SetOperationNode:
+		return heading(node.left)
+	case *ProductNode:
+		return concatHeadings(node.left, node.right)
+	case *JoinNode:
+		return concatHeadings(node.left, node.right)
+	default:
+		return nil, false
+	}
+}
+
+func concatHeadings(left, right AlgebraExpression) ([]string, bool) {
+	leftNames, leftKnown := heading(left)
+	rightNames, rightKnown := heading(right)
+	return append(slices.Clone(leftNames), rightNames...), leftKnown && rightKnown
+}
+
+// matchHeading finds the attributes of target that the names of a heading
+// stand for: the attribute of that name, or else the only one of the same
+// name qualified by another variable, as match pairs them.
+func matchHeading(names []string, target *entity.Relation) []string {
+	keys := attributes(target)
+	matched := make([]string, 0, len(names))
+	for _, name := range names {
+		if variable, isWildcard := strings.CutSuffix(name, ".*"); isWildcard {
+			for _, key := range keys {
+				if strings.HasPrefix(key, variable+".") {
+					matched = append(matched, key)
+				}
+			}
+
+			continue
+		}
+
+		candidates := make([]string, 0, 1)
+		for _, key := range keys {
+			if key == name || attributeName(key) == attributeName(name) {
+				candidates = append(candidates, key)
+			}
+		}
+
+		if len(candidates) == 1 {
+			name = candidates[0]
+		}
+
+		matched = append(matched, name)
+	}
+
+	return matched
+}
+
+// attributeName is the name of an attribute without its variable.
+func attributeName(name string) string {
+	_, attribute, isQualified := strings.Cut(name, ".")
+	if !isQualified {
+		return name
+	}
+
+	return attribute
+}
+
+func renameKeys(relation *entity.Relation, mapping map[string]string) *entity.Relation {
+	if len(mapping) == 0 {
+		return relation
+	}
+
+	renamed := make(entity.Relation, len(*relation))
+	for row := range *relation {
+		newRow := make(entity.RowMap, len(*row))
+		for key, values := range *row {
+			if newKey, isRenamed := mapping[key]; isRenamed {
+				key = newKey
+			}
+
+			newRow[key] = values
+		}
+
+		renamed[&newRow] = struct{}{}
+	}
+
+	return &renamed
+}
+
+// distinct converts the rows of a relation, dropping equal ones.
+func distinct(relation *entity.Relation, convert func(*entity.RowMap) *entity.RowMap) *entity.Relation {
+	result := make(entity.Relation, len(*relation))
+	seen := make(map[string]bool, len(*relation))
+	for row := range *relation {
+		converted := convert(row)
+		if key := rowKey(converted); !seen[key] {
+			seen[key] = true
+			result[converted] = struct{}{}
+		}
+	}
+
+	return &result
+}
+
+// qualifyNames turns the names in a condition that refer to attributes
+// without their variable into qualified attributes.
+func qualifyNames(expression Expression, keys []string) (Expression, error) {
+	var err error
+	switch node := expression.(type) {
+	case *IdentifierExpression:
+		if node.kind != model.FREE_RELATION.String() {
+			return node, nil
+		}
+
+		key, found, err := resolve(keys, node.value, node.position)
+		if err != nil || !found {
+			return node, err
+		}
+
+		return &IdentifierExpression{model.ATTRIBUTE.String(), key, node.position}, nil
+	case *BinaryExpression:
+		qualified := *node
+		if node.kind != model.EXISTS.String() && node.kind != model.FOR_ALL.String() {
+			if qualified.left, err = qualifyNames(node.left, keys); err != nil {
+				return nil, err
+			}
+		}
+
+		qualified.right, err = qualifyNames(node.right, keys)
+		return &qualified, err
+	case *UnaryExpression:
+		qualified := *node
+		qualified.expression, err = qualifyNames(node.expression, keys)
+		return &qualified, err
+	case *FunctionExpression:
+		qualified := *node
+		if node.qualification != nil {
+			qualified.qualification, err = qualifyNames(node.qualification, keys)
+		}
+
+		return &qualified, err
+	case *InExpression:
+		qualified := *node
+		qualified.values = make([]Expression, len(node.values))
+		for i, value := range node.values {
+			if qualified.values[i], err = qualifyNames(value, keys); err != nil {
+				return nil, err
+			}
+		}
+
+		qualified.operand, err = qualifyNames(node.operand, keys)
+		return &qualified, err
+	case *BetweenExpression:
+		qualified := *node
+		if qualified.lower, err = qualifyNames(node.lower, keys); err != nil {
+			return nil, err
+		}
+
+		if qualified.upper, err = qualifyNames(node.upper, keys); err != nil {
+			return nil, err
+		}
+
+		qualified.operand, err = qualifyNames(node.operand, keys)
+		return &qualified, err
+	case *PatternExpression:
+		qualified := *node
+		qualified.operand, err = qualifyNames(node.operand, keys)
+		return &qualified, err
+	default:
+		return expression, nil
+	}
+}
+
+// expand replaces every X.* in attributes by the attributes of variable X.
+func expand(relation *entity.Relation, attributes []string) []string {
+	expanded := make([]string, 0, len(attributes))
+	for _, attribute := range attributes {
+		variable, isWildcard := strings.CutSuffix(attribute, ".*")
+		if !isWildcard {
+			expanded = append(expanded, attribute)
+			continue
+		}
+
+		keys := make([]string, 0)
+		for row := range *relation {
+			for key := range *row {
+				if strings.HasPrefix(key, variable+".") && !slices.Contains(keys, key) {
+					keys = append(keys, key)
+				}
+			}
+		}
+
+		slices.Sort(keys)
+		expanded = append(expanded, keys...)
+	}
+
+	return expanded
+}
+
+// split separates a tuple with qualified attributes into the tuples of its
+// variables.
+func split(row *entity.RowMap) map[string]*entity.RowMap {
+	rows := make(map[string]*entity.RowMap)
+	for key, values := range *row {
+		variable, attribute, _ := strings.Cut(key, ".")
+		if rows[variable] == nil {
+			rows[variable] = &entity.RowMap{}
+		}
+
+		(*rows[variable])[attribute] = values
+	}
+
+	return rows
+}
+
+func qualify(row *entity.RowMap, variable string) *entity.RowMap {
+	qualified := make(entity.RowMap, len(*row))
+	for key, values := range *row {
+		qualified[variable+"."+key] = values
+	}
+
+	return &qualified
+}
+
+func unqualify(row *entity.RowMap) *entity.RowMap {
+	unqualified := make(entity.RowMap, len(*row))
+	for key, values := range *row {
+		if _, attribute, isQualified := strings.Cut(key, "."); isQualified {
+			key = attribute
+		}
+
+		unqualified[key] = append(unqualified[key], values...)
+	}
+
+	return &unqualified
+}
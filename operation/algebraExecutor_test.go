@@ -0,0 +1,83 @@
+package operation
+
+import (
+	"alpha-executor/entity"
+	"bufio"
+	"strings"
+	"testing"
+)
+
+// algebra executes relational algebra statements over the relations in
+// data and returns the workspaces they define.
+func algebra(t *testing.T, data string, source string) (entity.Workspaces, error) {
+	t.Helper()
+	statements, errors := GenerateAlgebra(bufio.NewReader(strings.NewReader(source)))
+	if len(errors) > 0 {
+		return nil, entity.CustomErrors(errors)
+	}
+
+	alphaRepository := newRepository()
+	alphaRepository.AddRelations(parseRelations(t, data))
+	executor := NewAlgebraExecutor(alphaRepository)
+	for _, statement := range statements {
+		if err := executor.Execute(statement); err != nil {
+			return nil, err
+		}
+	}
+
+	return alphaRepository.GetGetRelations(), nil
+}
+
+func TestAlgebraExecution(t *testing.T) {
+	tests := []struct {
+		source   string
+		expected string
+	}{
+		{"W := π[name](σ[salary > 95](EMP))", `[{"name": ["ann"]}, {"name": ["bob"]}]`},
+		{"W := PROJECT[name](SELECT[salary > 95](EMP))", `[{"name": ["ann"]}, {"name": ["bob"]}]`},
+		{"W := π[name, title](EMP ⋈ DEPT)", `[{"name": ["ann"], "title": ["sales"]}, {"name": ["bob"], "title": ["sales"]}, {"name": ["cid"], "title": ["it"]}, {"name": ["ann"], "title": ["ops"]}]`},
+		{"W := π[name, title](EMP JOIN σ[title = \"it\"](DEPT))", `[{"name": ["cid"], "title": ["it"]}]`},
+		{"W := π[E.name](ρ[E](EMP) ⋈[E.dept = F.dept ∧ E.id ≠ F.id] ρ[F](EMP))", `[{"name": ["ann"]}, {"name": ["bob"]}]`},
+		{"W := π[E.id](ρ[E](EMP) ⋈[E.salary > D.salary] ρ[D](σ[id = 1](EMP)))", `[{"id": ["2"]}, {"id": ["4"]}]`},
+		{"W := π[key](ρ[id → key](σ[id = 3](EMP)))", `[{"key": ["3"]}]`},
+		{"W := π[dept](EMP) − π[dept](σ[title = \"it\"](DEPT))", `[{"dept": ["d1"]}, {"dept": ["d3"]}]`},
+		{"W := π[dept](EMP) MINUS π[dept](σ[title = \"it\"](DEPT)) UNION π[dept](σ[id = 3](EMP))", `[{"dept": ["d1"]}, {"dept": ["d2"]}, {"dept": ["d3"]}]`},
+		{"W := π[dept](σ[salary > 95](EMP)) ∩ π[dept](σ[salary < 200](EMP))", `[{"dept": ["d1"]}]`},
+		{"W := π[name, dept](EMP) ÷ π[dept](σ[title ≠ \"ops\"](DEPT))", `[]`},
+		{"W := π[name, dept](EMP) ÷ π[dept](σ[title ≠ \"it\"](DEPT))", `[{"name": ["ann"]}]`},
+		{"W := π[name, dept](EMP) / π[dept](σ[title = \"ops\"](DEPT))", `[{"name": ["ann"]}]`},
+		{"W := π[name, dept](EMP) ÷ π[dept](σ[title = \"hr\"](DEPT))", `[{"name": ["ann"]}, {"name": ["bob"]}, {"name": ["cid"]}]`},
+		{"W := π[name, unit](ρ[dept → unit](EMP)) ÷ ρ[dept → unit](π[dept](σ[title = \"hr\"](DEPT)))", `[{"name": ["ann"]}, {"name": ["bob"]}, {"name": ["cid"]}]`},
+		{"W := π[E.name, E.dept](ρ[E](EMP)) ÷ ρ[E](π[dept](σ[title = \"hr\"](DEPT)))", `[{"name": ["ann"]}, {"name": ["bob"]}, {"name": ["cid"]}]`},
+	}
+
+	for _, test := range tests {
+		workspaces, err := algebra(t, employees, test.source)
+		if err != nil {
+			t.Fatalf("%s: %v", test.source, err)
+		}
+
+		expectRows(t, workspaces["W"].Relation, test.expected)
+	}
+}
+
+func TestAlgebraExecutionErrors(t *testing.T) {
+	tests := []struct {
+		source  string
+		message string
+	}{
+		{"W := π[name](EMP) ∪ π[title](DEPT)", "Incorrect arity"},
+		{"W := π[name](EMP) ∩ π[name, id](EMP)", "Incorrect arity"},
+		{"W := π[dept](EMP) − DEPT", "Incorrect arity"},
+		{"W := EMP × EMP", "is in both operands of a product"},
+		{"W := π[name](ρ[E](EMP) × ρ[F](EMP))", "attribute name is ambiguous"},
+		{"W := ρ[grade → level](EMP)", "attribute grade doesn't exist"},
+		{"W := π[name](NOPE)", "NOPE"},
+		{"W := EMP ÷ (σ[title = \"hr\"](DEPT) ÷ DEPT)", "Unknown attributes of the empty divisor"},
+	}
+
+	for _, test := range tests {
+		_, err := algebra(t, employees, test.source)
+		expectError(t, err, test.message)
+	}
+}
@@ -0,0 +1,320 @@
+package operation
+
+import (
+	"alpha-executor/entity"
+	"alpha-executor/model"
+	"bufio"
+)
+
+// AlgebraParser parses relational algebra statements W := E. Union,
+// intersection and difference bind less tightly than product, join and
+// division; all of them are left associative. A relation name R stands for
+// ρ[R](R), so that its attributes are qualified by R.
+type AlgebraParser struct {
+	parser *Parser
+}
+
+func NewAlgebraParser(tokens []*model.Token) *AlgebraParser {
+	return &AlgebraParser{
+		parser: NewParser(tokens),
+	}
+}
+
+func GenerateAlgebra(reader *bufio.Reader) ([]*AlgebraStatement, []*entity.CustomError) {
+	statements := make([]*AlgebraStatement, 0)
+	lexer := model.NewAlgebraLexer(reader)
+	output := lexer.Lex()
+	errors := lexer.Errors()
+	for _, query := range output {
+		if len(query) > 0 && !hasIllegalToken(query) {
+			parser := NewAlgebraParser(query)
+			statement, err := parser.ParseStatement()
+			if err != nil {
+				errors = append(errors, err.(*entity.CustomError))
+				continue
+			}
+
+			statements = append(statements, statement)
+		}
+	}
+
+	return statements, errors
+}
+
+func (a *AlgebraParser) ParseStatement() (*AlgebraStatement, error) {
+	workspace, err := a.parser.expect(model.FREE_RELATION)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = a.parser.expect(model.DEFINE); err != nil {
+		return nil, err
+	}
+
+	expression, err := a.parseSetOperation()
+	if err != nil {
+		return nil, err
+	}
+
+	if next := a.parser.peek(); next.Type != model.EOF {
+		return nil, a.parser.error(next, "unexpected %s", a.parser.describe(next))
+	}
+
+	return &AlgebraStatement{model.DEFINE.String(), workspace.Value, expression}, nil
+}
+
+func (a *AlgebraParser) parseSetOperation() (AlgebraExpression, error) {
+	left, err := a.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		operator := a.parser.peek()
+		if operator.Type != model.UNION && operator.Type != model.INTERSECTION && operator.Type != model.DIFFERENCE {
+			return left, nil
+		}
+
+		a.parser.next()
+		right, err := a.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &SetOperationNode{operator.Type.String(), left, right, operator.Position}
+	}
+}
+
+func (a *AlgebraParser) parseProduct() (AlgebraExpression, error) {
+	left, err := a.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		operator := a.parser.peek()
+		if operator.Type != model.PRODUCT && operator.Type != model.JOIN && operator.Type != model.DIVISION {
+			return left, nil
+		}
+
+		a.parser.next()
+		var condition Expression
+		if operator.Type == model.JOIN && a.parser.peek().Type == model.LEFT_BRACKET {
+			if condition, err = a.parseCondition(); err != nil {
+				return nil, err
+			}
+		}
+
+		right, err := a.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+
+		switch operator.Type {
+		case model.PRODUCT:
+			left = &ProductNode{left, right, operator.Position}
+		case model.JOIN:
+			left = &JoinNode{left, right, condition, operator.Position}
+		default:
+			left = &DivisionNode{left, right, operator.Position}
+		}
+	}
+}
+
+func (a *AlgebraParser) parsePrimary() (AlgebraExpression, error) {
+	token, err := a.parser.next()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token.Type {
+	case model.FREE_RELATION:
+		return &RenameNode{token.Value, &RelationNode{token.Value, token.Position}}, nil
+	case model.LEFT_PARENTHESIS:
+		expression, err := a.parseSetOperation()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err = a.parser.expect(model.RIGHT_PARENTHESIS); err != nil {
+			return nil, err
+		}
+
+		return expression, nil
+	case model.SELECTION:
+		condition, err := a.parseCondition()
+		if err != nil {
+			return nil, err
+		}
+
+		child, err := a.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+
+		return &SelectionNode{condition, child, true}, nil
+	case model.PROJECTION:
+		attributes, err := a.parseAttributes()
+		if err != nil {
+			return nil, err
+		}
+
+		child, err := a.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+
+		return &ProjectionNode{attributes, child, token.Position}, nil
+	case model.RENAME:
+		return a.parseRename(token.Position)
+	default:
+		return nil, a.parser.error(token, "unexpected %s", a.parser.describe(token))
+	}
+}
+
+// parseOperand parses the parenthesised operand of a unary operator.
+func (a *AlgebraParser) parseOperand() (AlgebraExpression, error) {
+	if _, err := a.parser.expect(model.LEFT_PARENTHESIS); err != nil {
+		return nil, err
+	}
+
+	expression, err := a.parseSetOperation()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = a.parser.expect(model.RIGHT_PARENTHESIS); err != nil {
+		return nil, err
+	}
+
+	return expression, nil
+}
+
+// parseCondition parses a bracketed ALPHA qualification with the parser of
+// ALPHA.
+func (a *AlgebraParser) parseCondition() (Expression, error) {
+	if _, err := a.parser.expect(model.LEFT_BRACKET); err != nil {
+		return nil, err
+	}
+
+	end := 0
+	for end < len(a.parser.tokens) && a.parser.tokens[end].Type != model.RIGHT_BRACKET {
+		end++
+	}
+
+	if end == 0 {
+		return nil, a.parser.error(a.parser.peek(), "expected a condition, got %s", a.parser.describe(a.parser.peek()))
+	}
+
+	parser := NewParser(a.parser.tokens[:end])
+	if end < len(a.parser.tokens) {
+		parser.end = a.parser.tokens[end].Position
+	}
+
+	condition, err := parser.parseImplication()
+	if err != nil {
+		return nil, err
+	}
+
+	if next := parser.peek(); next.Type != model.EOF {
+		return nil, parser.error(next, "unexpected %s", parser.describe(next))
+	}
+
+	a.parser.tokens = a.parser.tokens[end:]
+	if _, err = a.parser.expect(model.RIGHT_BRACKET); err != nil {
+		return nil, err
+	}
+
+	return condition, nil
+}
+
+func (a *AlgebraParser) parseAttributes() ([]string, error) {
+	if _, err := a.parser.expect(model.LEFT_BRACKET); err != nil {
+		return nil, err
+	}
+
+	attributes := make([]string, 0)
+	for {
+		token := a.parser.peek()
+		if token.Type != model.FREE_RELATION && token.Type != model.ATTRIBUTE {
+			return nil, a.parser.error(token, "expected an attribute, got %s", a.parser.describe(token))
+		}
+
+		a.parser.next()
+		attributes = append(attributes, token.Value)
+		if a.parser.peek().Type != model.COMMA {
+			break
+		}
+
+		a.parser.next()
+	}
+
+	if _, err := a.parser.expect(model.RIGHT_BRACKET); err != nil {
+		return nil, err
+	}
+
+	return attributes, nil
+}
+
+// parseRename parses ρ[X](E), which qualifies the attributes of E by X, and
+// ρ[a → b, …](E), which renames attributes of E.
+func (a *AlgebraParser) parseRename(position entity.Position) (AlgebraExpression, error) {
+	if _, err := a.parser.expect(model.LEFT_BRACKET); err != nil {
+		return nil, err
+	}
+
+	if len(a.parser.tokens) > 1 && a.parser.tokens[1].Type == model.RIGHT_BRACKET {
+		variable, err := a.parser.expect(model.FREE_RELATION)
+		if err != nil {
+			return nil, err
+		}
+
+		a.parser.next()
+		child, err := a.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+
+		if renamed, isRenamed := child.(*RenameNode); isRenamed {
+			child = renamed.child
+		}
+
+		return &RenameNode{variable.Value, child}, nil
+	}
+
+	renames := make([]entity.Pair[string, string], 0)
+	for {
+		old := a.parser.peek()
+		if old.Type != model.FREE_RELATION && old.Type != model.ATTRIBUTE {
+			return nil, a.parser.error(old, "expected an attribute, got %s", a.parser.describe(old))
+		}
+
+		a.parser.next()
+		if _, err := a.parser.expect(model.IMPLICATION); err != nil {
+			return nil, err
+		}
+
+		name, err := a.parser.expect(model.FREE_RELATION)
+		if err != nil {
+			return nil, err
+		}
+
+		renames = append(renames, entity.Pair[string, string]{Left: old.Value, Right: name.Value})
+		if a.parser.peek().Type != model.COMMA {
+			break
+		}
+
+		a.parser.next()
+	}
+
+	if _, err := a.parser.expect(model.RIGHT_BRACKET); err != nil {
+		return nil, err
+	}
+
+	child, err := a.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	return &RenameAttributesNode{renames, child, position}, nil
+}
@@ -0,0 +1,57 @@
+package operation
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+func TestAlgebraPrecedence(t *testing.T) {
+	tests := []struct {
+		source   string
+		expected string
+	}{
+		{"W := A ∪ B × C", "W := ρ[A](A) ∪ (ρ[B](B) × ρ[C](C))"},
+		{"W := A × B ∪ C", "W := (ρ[A](A) × ρ[B](B)) ∪ ρ[C](C)"},
+		{"W := A − B ∩ C", "W := (ρ[A](A) − ρ[B](B)) ∩ ρ[C](C)"},
+		{"W := A ⋈ B ÷ C", "W := (ρ[A](A) ⋈ ρ[B](B)) ÷ ρ[C](C)"},
+		{"W := A ∪ B ⋈ C − D", "W := (ρ[A](A) ∪ (ρ[B](B) ⋈ ρ[C](C))) − ρ[D](D)"},
+		{"W := A ∪ (B − C)", "W := ρ[A](A) ∪ (ρ[B](B) − ρ[C](C))"},
+		{"W := π[x](A) × B", "W := π[x](ρ[A](A)) × ρ[B](B)"},
+		{"W := ρ[X](A) ⋈[X.a = B.a] B", "W := ρ[X](A) ⋈[X.a = B.a] ρ[B](B)"},
+		{"W := ρ[a → b, c → d](A)", "W := ρ[a → b, c → d](ρ[A](A))"},
+	}
+
+	for _, test := range tests {
+		statements, errors := GenerateAlgebra(bufio.NewReader(strings.NewReader(test.source)))
+		if len(errors) > 0 {
+			t.Fatalf("%s: %v", test.source, errors)
+		}
+
+		if len(statements) != 1 || statements[0].String() != test.expected {
+			t.Errorf("%s: got %v, want %s", test.source, statements, test.expected)
+		}
+	}
+}
+
+func TestAlgebraSyntaxErrors(t *testing.T) {
+	tests := []struct {
+		source  string
+		message string
+	}{
+		{"W := σ[salary > 95(EMP)", "unterminated condition"},
+		{"W := π[](EMP)", "expected an attribute"},
+		{"W := σ[](EMP)", "expected a condition"},
+		{"W := (A ∪ B", "expected )"},
+		{"W := A ∪", "unexpected"},
+		{"W := A B", "unexpected"},
+		{"W = A", "illegal character"},
+	}
+
+	for _, test := range tests {
+		_, errors := GenerateAlgebra(bufio.NewReader(strings.NewReader(test.source)))
+		if len(errors) == 0 || !strings.Contains(errors[0].Message, test.message) {
+			t.Errorf("%s: got %v, want an error containing %q", test.source, errors, test.message)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package operation
+
+import (
+	"alpha-executor/entity"
+	"alpha-executor/model"
+	"fmt"
+	"strconv"
+)
+
+func isArithmetic(kind string) bool {
+	switch kind {
+	case model.PLUS.String(), model.MINUS.String(), model.MULTIPLY.String(), model.DIVIDE.String(), model.UNARY_MINUS.String():
+		return true
+	default:
+		return false
+	}
+}
+
+func (i *Interpreter) evaluateArithmetic(expression *BinaryExpression) ([]string, error) {
+	leftValues, err := i.evaluateOperand(expression.left)
+	if err != nil {
+		return nil, err
+	}
+
+	rightValues, err := i.evaluateOperand(expression.right)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(leftValues)*len(rightValues))
+	for _, leftValue := range leftValues {
+		left, err := i.parseNumber(leftValue, expression.position)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rightValue := range rightValues {
+			right, err := i.parseNumber(rightValue, expression.position)
+			if err != nil {
+				return nil, err
+			}
+
+			var value float64
+			switch expression.kind {
+			case model.PLUS.String():
+				value = left + right
+			case model.MINUS.String():
+				value = left - right
+			case model.MULTIPLY.String():
+				value = left * right
+			case model.DIVIDE.String():
+				if right == 0 {
+					return nil, &entity.CustomError{
+						ErrorType: entity.ResponseTypes["RT"],
+						Message:   "division by zero",
+						Position:  expression.position,
+					}
+				}
+
+				value = left / right
+			default:
+				return nil, &entity.CustomError{
+					ErrorType: entity.ResponseTypes["CE"],
+					Message:   fmt.Sprintf("Unknown operator %s", expression.kind),
+					Position:  expression.position,
+				}
+			}
+
+			result = append(result, strconv.FormatFloat(value, 'f', -1, 64))
+		}
+	}
+
+	return result, nil
+}
+
+func (i *Interpreter) evaluateUnaryMinus(expression *UnaryExpression) ([]string, error) {
+	values, err := i.evaluateOperand(expression.expression)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		number, err := i.parseNumber(value, expression.position)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, strconv.FormatFloat(-number, 'f', -1, 64))
+	}
+
+	return result, nil
+}
+
+func (i *Interpreter) parseNumber(value string, position entity.Position) (float64, error) {
+	number, err := strconv.ParseFloat(value, 10)
+	if err != nil {
+		return 0, &entity.CustomError{
+			ErrorType: entity.ResponseTypes["RT"],
+			Message:   fmt.Sprintf("arithmetic on non-numeric value %s", value),
+			Position:  position,
+		}
+	}
+
+	return number, nil
+}
+
+// attributeRelations lists the relations whose attributes an arithmetic
+// expression reads, so that a computed column can be bound to their rows.
+func attributeRelations(expression Expression) []string {
+	switch operand := expression.(type) {
+	case *IdentifierExpression:
+		if operand.kind != model.ATTRIBUTE.String() {
+			return nil
+		}
+
+		attr := model.Attribute{}
+		attribute, err := attr.ExtractAttribute(operand.value, operand.position)
+		if err != nil {
+			return nil
+		}
+
+		return []string{attribute.Relation}
+	case *BinaryExpression:
+		return append(attributeRelations(operand.left), attributeRelations(operand.right)...)
+	case *UnaryExpression:
+		return attributeRelations(operand.expression)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,34 @@
+package operation
+
+import "testing"
+
+func TestArithmeticInQualification(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{`GET W (EMP.id): EMP.salary - EMP.id * 100 >= 0`, `[{"id": ["1"]}, {"id": ["2"]}]`},
+		{`GET W (EMP.id): (EMP.salary - EMP.id) * 2 > 400`, `[{"id": ["2"]}, {"id": ["4"]}]`},
+		{`GET W (EMP.id): EMP.salary * 1.1 > 270`, `[{"id": ["2"]}, {"id": ["4"]}]`},
+		{`GET W (EMP.id): -EMP.salary < -100`, `[{"id": ["2"]}, {"id": ["4"]}]`},
+	}
+
+	for _, test := range tests {
+		expectWorkspace(t, employees, test.query, "W", test.expected)
+	}
+}
+
+func TestArithmeticInTargetList(t *testing.T) {
+	expectWorkspace(t, employees, `GET W (EMP.id, EMP.salary * 2): EMP.salary * 2 > 400`, "W",
+		`[{"id": ["2"], "EMP.salary * 2": ["500"]}, {"id": ["4"], "EMP.salary * 2": ["600"]}]`)
+	expectWorkspace(t, employees, `GET W (EMP.id, -EMP.salary / 4): EMP.dept = "d1"`, "W",
+		`[{"id": ["1"], "-EMP.salary / 4": ["-25"]}, {"id": ["2"], "-EMP.salary / 4": ["-62.5"]}]`)
+}
+
+func TestArithmeticErrors(t *testing.T) {
+	_, _, err := run(t, employees, `GET W (EMP.id): EMP.salary + EMP.name > 1`)
+	expectError(t, err, "arithmetic on string value")
+
+	_, _, err = run(t, employees, `GET W (EMP.id): EMP.salary / 0 > 1`)
+	expectError(t, err, "division by zero")
+}
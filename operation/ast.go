@@ -4,6 +4,7 @@ import (
 	"alpha-executor/entity"
 	"alpha-executor/model"
 	"bufio"
+	"regexp"
 )
 
 type Expression interface {
@@ -50,13 +51,61 @@ func (i *IdentifierExpression) GetKind() string {
 	return i.kind
 }
 
+type FunctionExpression struct {
+	kind          string
+	argument      Expression
+	qualification Expression
+	position      entity.Position
+}
+
+func (f *FunctionExpression) GetKind() string {
+	return f.kind
+}
+
+type InExpression struct {
+	kind     string
+	operand  Expression
+	values   []Expression
+	position entity.Position
+}
+
+func (i *InExpression) GetKind() string {
+	return i.kind
+}
+
+type BetweenExpression struct {
+	kind     string
+	operand  Expression
+	lower    Expression
+	upper    Expression
+	position entity.Position
+}
+
+func (b *BetweenExpression) GetKind() string {
+	return b.kind
+}
+
+// PatternExpression is a LIKE or regular expression match; the pattern is
+// compiled by the parser, so that a malformed one is a compilation error.
+type PatternExpression struct {
+	kind     string
+	operand  Expression
+	pattern  *IdentifierExpression
+	matcher  *regexp.Regexp
+	position entity.Position
+}
+
+func (p *PatternExpression) GetKind() string {
+	return p.kind
+}
+
 type GetHoldExpression struct {
 	kind       string
 	variable   Expression
 	rows       Expression
 	relations  []Expression
 	expression Expression
-	sort       Expression
+	sort       []Expression
 	position   entity.Position
 }
 
@@ -86,17 +135,34 @@ func (p *PutExpression) GetKind() string {
 	return p.kind
 }
 
-func GenerateAST(reader *bufio.Reader) Program {
+func GenerateAST(reader *bufio.Reader) (Program, []*entity.CustomError) {
 	program := Program{model.PROGRAM.String(), make([]Expression, 0)}
+	errors := make([]*entity.CustomError, 0)
 
 	lexer := model.NewLexer(reader)
 	output := lexer.Lex()
+	errors = append(errors, lexer.Errors()...)
 	for _, query := range output {
-		if len(query) > 0 {
+		if len(query) > 0 && !hasIllegalToken(query) {
 			parser := NewParser(query)
-			program.body = append(program.body, parser.ParseFullExpression())
+			expression, err := parser.ParseFullExpression()
+			if err != nil {
+				errors = append(errors, err.(*entity.CustomError))
+				continue
+			}
+
+			program.body = append(program.body, expression)
 		}
 	}
 
-	return program
+	return program, errors
+}
+
+func hasIllegalToken(tokens []*model.Token) bool {
+	for _, token := range tokens {
+		if token.Type == model.ILLEGAL {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,281 @@
+package operation
+
+import (
+	"alpha-executor/entity"
+	"alpha-executor/model"
+	"fmt"
+	"maps"
+)
+
+// Binder resolves the relations, range variables and attributes a program
+// refers to before it is interpreted. The interpreter finds a wrong name only
+// when some tuple reaches it; the binder reports every one of them up front.
+type Binder struct {
+	scope      *scope
+	held       map[string]*entity.Schema
+	workspaces map[string]bool
+	errors     []*entity.CustomError
+}
+
+func NewBinder(schemas entity.Schemas) *Binder {
+	return &Binder{
+		scope:      newScope(schemas),
+		held:       make(map[string]*entity.Schema),
+		workspaces: make(map[string]bool),
+		errors:     make([]*entity.CustomError, 0),
+	}
+}
+
+func (b *Binder) Bind(program *Program) []*entity.CustomError {
+	for _, expression := range program.body {
+		switch statement := expression.(type) {
+		case *RangeExpression:
+			b.bindRange(statement)
+		case *GetHoldExpression:
+			b.bindGetHold(statement)
+		case *BinaryExpression:
+			if statement.kind == model.ASSIGN.String() {
+				b.bindAssignment(statement)
+			}
+		case *UnaryExpression:
+			b.bindHeld(statement)
+		case *PutExpression:
+			b.bindPut(statement)
+		}
+	}
+
+	return b.errors
+}
+
+func (b *Binder) bindRange(expression *RangeExpression) {
+	relation := expression.relation.(*IdentifierExpression)
+	if !b.known(relation.value) {
+		b.error(relation.position, "unknown relation %s", relation.value)
+	}
+
+	b.scope.declareRange(expression.variable.(*IdentifierExpression).value, relation.value)
+}
+
+func (b *Binder) bindGetHold(expression *GetHoldExpression) {
+	free := make(map[string]bool)
+	for _, target := range expression.relations {
+		identifier, isIdentifier := target.(*IdentifierExpression)
+		if isIdentifier && identifier.kind == model.FREE_RELATION.String() {
+			if !b.known(identifier.value) {
+				b.error(identifier.position, "unknown relation %s", identifier.value)
+			}
+
+			free[identifier.value] = true
+		}
+
+		// An unknown relation stays unbound, so bindAttribute reports it.
+		for _, relation := range attributeRelations(target) {
+			if b.known(relation) {
+				free[relation] = true
+			}
+		}
+	}
+
+	for _, target := range expression.relations {
+		b.bindQualification(target, free)
+	}
+
+	if expression.expression != nil {
+		b.bindQualification(expression.expression, free)
+	}
+
+	for _, key := range expression.sort {
+		b.bindQualification(key.(*UnaryExpression).expression, free)
+	}
+
+	name := expression.variable.(*IdentifierExpression).value
+	schema := b.scope.workspaceSchema(expression.relations, func(Expression) entity.AttributeType {
+		return entity.UnknownType
+	})
+	if expression.kind == model.GET.String() {
+		b.workspaces[name] = true
+		b.scope.declareWorkspace(name, schema)
+	} else {
+		b.held[name] = schema
+	}
+}
+
+// bindQualification checks the names of a qualification or an operand;
+// bound holds the relations whose tuples are bound at that point: the free
+// variables of the target list and the enclosing quantifiers.
+func (b *Binder) bindQualification(expression Expression, bound map[string]bool) {
+	switch node := expression.(type) {
+	case *BinaryExpression:
+		if node.kind != model.EXISTS.String() && node.kind != model.FOR_ALL.String() {
+			b.bindQualification(node.left, bound)
+			b.bindQualification(node.right, bound)
+			return
+		}
+
+		variable := node.left.(*IdentifierExpression)
+		if !b.known(variable.value) {
+			b.error(variable.position, "undeclared range variable %s", variable.value)
+		}
+
+		b.bindQualification(node.right, with(bound, variable.value))
+	case *UnaryExpression:
+		b.bindQualification(node.expression, bound)
+	case *InExpression:
+		b.bindQualification(node.operand, bound)
+		for _, value := range node.values {
+			b.bindQualification(value, bound)
+		}
+	case *BetweenExpression:
+		b.bindQualification(node.operand, bound)
+		b.bindQualification(node.lower, bound)
+		b.bindQualification(node.upper, bound)
+	case *PatternExpression:
+		b.bindQualification(node.operand, bound)
+	case *FunctionExpression:
+		b.bindFunction(node, bound)
+	case *IdentifierExpression:
+		if node.kind == model.ATTRIBUTE.String() {
+			b.bindAttribute(node, bound)
+		}
+	}
+}
+
+func (b *Binder) bindFunction(expression *FunctionExpression, bound map[string]bool) {
+	argument := expression.argument.(*IdentifierExpression)
+	relation := argument.value
+	if argument.kind == model.ATTRIBUTE.String() {
+		attr := model.Attribute{}
+		attribute, err := attr.ExtractAttribute(argument.value, argument.position)
+		if err != nil {
+			b.errors = append(b.errors, err.(*entity.CustomError))
+			return
+		}
+
+		relation = attribute.Relation
+	}
+
+	if !b.known(relation) {
+		b.error(argument.position, "unknown relation %s", relation)
+		return
+	}
+
+	inner := with(bound, relation)
+	if argument.kind == model.ATTRIBUTE.String() {
+		b.bindAttribute(argument, inner)
+	}
+
+	if expression.qualification != nil {
+		b.bindQualification(expression.qualification, inner)
+	}
+}
+
+func (b *Binder) bindAttribute(identifier *IdentifierExpression, bound map[string]bool) {
+	attr := model.Attribute{}
+	attribute, err := attr.ExtractAttribute(identifier.value, identifier.position)
+	if err != nil {
+		b.errors = append(b.errors, err.(*entity.CustomError))
+		return
+	}
+
+	if !b.known(attribute.Relation) {
+		// An undeclared quantifier variable is reported by its quantifier.
+		if !bound[attribute.Relation] {
+			b.error(identifier.position, "unknown relation or range variable %s", attribute.Relation)
+		}
+		return
+	}
+
+	if !bound[attribute.Relation] {
+		b.error(identifier.position, "free variable %s is not in the target list", attribute.Relation)
+		return
+	}
+
+	schema, exists := b.scope.schema(attribute.Relation)
+	b.checkAttribute(schema, exists, attribute, identifier.position)
+}
+
+func (b *Binder) bindAssignment(expression *BinaryExpression) {
+	identifier := expression.left.(*IdentifierExpression)
+	attr := model.Attribute{}
+	attribute, err := attr.ExtractAttribute(identifier.value, identifier.position)
+	if err != nil {
+		b.errors = append(b.errors, err.(*entity.CustomError))
+		return
+	}
+
+	schema, exists := b.held[attribute.Relation]
+	if !exists {
+		b.error(identifier.position, "workspace %s is not held", attribute.Relation)
+		return
+	}
+
+	b.checkAttribute(schema, exists, attribute, identifier.position)
+}
+
+func (b *Binder) bindHeld(expression *UnaryExpression) {
+	identifier := expression.expression.(*IdentifierExpression)
+	schema, exists := b.held[identifier.value]
+	if !exists {
+		b.error(identifier.position, "workspace %s is not held", identifier.value)
+		return
+	}
+
+	switch expression.kind {
+	case model.UPDATE.String():
+		b.scope.declareWorkspace(identifier.value, schema)
+	case model.RELEASE.String(), model.DELETE.String():
+		delete(b.held, identifier.value)
+	}
+}
+
+func (b *Binder) bindPut(expression *PutExpression) {
+	workspace := expression.variable.(*IdentifierExpression)
+	if _, isHeld := b.held[workspace.value]; !isHeld && !b.workspaces[workspace.value] {
+		b.error(workspace.position, "unknown workspace %s", workspace.value)
+	}
+
+	for _, relation := range expression.relations {
+		identifier, isIdentifier := relation.(*IdentifierExpression)
+		if isIdentifier && identifier.kind == model.FREE_RELATION.String() && !b.known(identifier.value) {
+			b.error(identifier.position, "unknown relation %s", identifier.value)
+		}
+	}
+}
+
+// checkAttribute reports an attribute missing from a known schema. Relations
+// without any tuples have no inferred attributes and are not checked.
+func (b *Binder) checkAttribute(
+	schema *entity.Schema,
+	exists bool,
+	attribute model.ComplexAttribute,
+	position entity.Position,
+) {
+	if !exists || len(*schema) == 0 {
+		return
+	}
+
+	if _, exists = schema.Attribute(attribute.Attribute); !exists {
+		b.error(position, "incorrect attribute %s of %s", attribute.Attribute, attribute.Relation)
+	}
+}
+
+// known reports whether name is a relation, a workspace or a range variable.
+// A range variable over an unknown relation was reported where it was declared.
+func (b *Binder) known(name string) bool {
+	_, exists := b.scope.schema(name)
+	return exists || b.scope.isRange(name)
+}
+
+func (b *Binder) error(position entity.Position, format string, args ...any) {
+	b.errors = append(b.errors, &entity.CustomError{
+		ErrorType: entity.ResponseTypes["CE"],
+		Message:   fmt.Sprintf(format, args...),
+		Position:  position,
+	})
+}
+
+func with(bound map[string]bool, relation string) map[string]bool {
+	inner := maps.Clone(bound)
+	inner[relation] = true
+	return inner
+}
@@ -0,0 +1,45 @@
+package operation
+
+import (
+	"alpha-executor/entity"
+	"testing"
+)
+
+func TestBinderErrors(t *testing.T) {
+	tests := []struct {
+		query   string
+		message string
+	}{
+		{`RANGE EMPL E; GET W (DEPT.dept)`, "unknown relation EMPL at 1 line, 7 column"},
+		{`GET W (EMPL)`, "unknown relation EMPL at 1 line, 8 column"},
+		{`GET W (EMPL.id)`, "unknown relation or range variable EMPL at 1 line, 8 column"},
+		{`GET W (COUNT(EMPL))`, "unknown relation EMPL at 1 line, 14 column"},
+		{`GET W (DEPT.dept): ∃E (E.dept = DEPT.dept)`, "undeclared range variable E at 1 line, 21 column"},
+		{`GET W (EMP.nme)`, "incorrect attribute nme of EMP at 1 line, 8 column"},
+		{`RANGE EMP E; GET W (DEPT.dept): ∃E (E.dept = DEPT.dept ∧ E.grade = 1)`, "incorrect attribute grade of E at 1 line, 58 column"},
+		{`GET W (DEPT.dept): EMP.dept = DEPT.dept`, "free variable EMP is not in the target list at 1 line, 20 column"},
+		{`GET W (DEPT.dept): X.dept = DEPT.dept`, "unknown relation or range variable X at 1 line, 20 column"},
+		{`W.id = 1`, "workspace W is not held at 1 line, 1 column"},
+		{`HOLD W (EMP.id): EMP.id = 1; W.name = "x"`, "incorrect attribute name of W at 1 line, 30 column"},
+		{`UPDATE W`, "workspace W is not held at 1 line, 8 column"},
+		{`PUT W (EMP)`, "unknown workspace W at 1 line, 5 column"},
+		{`GET W (EMP); PUT W (EMPL)`, "unknown relation EMPL at 1 line, 21 column"},
+	}
+
+	for _, test := range tests {
+		_, _, err := run(t, employees, test.query)
+		expectError(t, err, "Compilation Error: "+test.message)
+	}
+}
+
+func TestBinderReportsEveryError(t *testing.T) {
+	query := `HOLD H (EMP): EMP.id = 1; DELETE H; GET W (EMP.nme); RANGE DEP D; GET V (DEPT.dept): ∃D (D.x = 1)`
+	_, _, err := run(t, employees, query)
+	errors, isList := err.(entity.CustomErrors)
+	if !isList || len(errors) != 2 {
+		t.Fatalf("got %v, want two errors", err)
+	}
+
+	expectError(t, errors[0], "incorrect attribute nme of EMP at 1 line, 44 column")
+	expectError(t, errors[1], "unknown relation DEP at 1 line, 60 column")
+}
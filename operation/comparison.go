@@ -4,9 +4,11 @@ import (
 	"alpha-executor/entity"
 	"alpha-executor/model"
 	"alpha-executor/repository"
+	"cmp"
 	"fmt"
+	"slices"
 	"strconv"
-	"time"
+	"strings"
 )
 
 type Comparison struct {
@@ -25,7 +27,7 @@ func NewComparison(repository *repository.AlphaRepository) *Comparison {
 	return &Comparison{repository: repository}
 }
 
-func (c *Comparison) Compare(params *BinaryExpression) (bool, error) {
+func (c *Comparison) Compare(params *BinaryExpression) (TruthValue, error) {
 	c.parameters = &parameters{
 		kind:     params.kind,
 		left:     *params.left.(*IdentifierExpression),
@@ -33,44 +35,76 @@ func (c *Comparison) Compare(params *BinaryExpression) (bool, error) {
 		position: params.position,
 	}
 
-	attr := model.Attribute{}
-
-	attributeLeft, err := attr.ExtractAttribute(c.parameters.left.value, c.parameters.position)
+	attributeLeft, err := c.extractAttribute(c.parameters.left)
 	if err != nil {
-		return false, err
+		return FALSE, err
 	}
 
-	attributeRight, err := attr.ExtractAttribute(c.parameters.right.value, c.parameters.position)
+	attributeRight, err := c.extractAttribute(c.parameters.right)
 	if err != nil {
-		return false, err
+		return FALSE, err
 	}
 
 	if c.parameters.left.kind == model.ATTRIBUTE.String() && c.parameters.right.kind == model.ATTRIBUTE.String() {
 		return c.twoAttributesCompare(attributeLeft, attributeRight)
 	} else if c.parameters.left.kind == model.ATTRIBUTE.String() {
-		return c.oneAttributeCompare(attributeLeft)
+		return c.oneAttributeCompare(attributeLeft, &c.parameters.left)
 	} else if c.parameters.right.kind == model.ATTRIBUTE.String() {
-		return c.oneAttributeCompare(attributeRight)
+		return c.oneAttributeCompare(attributeRight, &c.parameters.right)
+	}
+
+	return FALSE, nil
+}
+
+func (c *Comparison) extractAttribute(operand IdentifierExpression) (model.ComplexAttribute, error) {
+	if operand.kind != model.ATTRIBUTE.String() {
+		return model.ComplexAttribute{}, nil
+	}
+
+	attr := model.Attribute{}
+	return attr.ExtractAttribute(operand.value, c.parameters.position)
+}
+
+func (c *Comparison) CompareValues(kind string, left, right []string, position entity.Position) (TruthValue, error) {
+	c.parameters = &parameters{
+		kind:     kind,
+		position: position,
+	}
+
+	if len(left) == 0 || len(right) == 0 {
+		return UNKNOWN, nil
+	}
+
+	for _, valLeft := range left {
+		for _, valRight := range right {
+			c.parameters.left.value = valLeft
+			c.parameters.right.value = valRight
+			if isTrue, err := c.valueComparator(); isTrue && err == nil {
+				return TRUE, nil
+			} else if err != nil {
+				return FALSE, err
+			}
+		}
 	}
 
-	return false, nil
+	return FALSE, nil
 }
 
-func (c *Comparison) twoAttributesCompare(attributeLeft, attributeRight model.ComplexAttribute) (bool, error) {
+func (c *Comparison) twoAttributesCompare(attributeLeft, attributeRight model.ComplexAttribute) (TruthValue, error) {
 	relation1, err := c.repository.GetRow(attributeLeft.Relation)
 	if err != nil {
 		err.(*entity.CustomError).Position = c.parameters.position
-		return false, err
+		return FALSE, err
 	}
 
 	relation2, err := c.repository.GetRow(attributeRight.Relation)
 	if err != nil {
 		err.(*entity.CustomError).Position = c.parameters.position
-		return false, err
+		return FALSE, err
 	}
 
 	if c.incorrectAttribute(relation1, attributeLeft.Attribute) {
-		return false, &entity.CustomError{
+		return FALSE, &entity.CustomError{
 			ErrorType: entity.ResponseTypes["CE"],
 			Message:   fmt.Sprintf("incorrect attribute %s", attributeLeft.Attribute),
 			Position:  c.parameters.position,
@@ -78,7 +112,7 @@ func (c *Comparison) twoAttributesCompare(attributeLeft, attributeRight model.Co
 	}
 
 	if c.incorrectAttribute(relation2, attributeRight.Attribute) {
-		return false, &entity.CustomError{
+		return FALSE, &entity.CustomError{
 			ErrorType: entity.ResponseTypes["CE"],
 			Message:   fmt.Sprintf("incorrect attribute %s", attributeRight.Attribute),
 			Position:  c.parameters.position,
@@ -87,6 +121,10 @@ func (c *Comparison) twoAttributesCompare(attributeLeft, attributeRight model.Co
 
 	valuesLeft := (*relation1)[attributeLeft.Attribute]
 	valuesRight := (*relation2)[attributeRight.Attribute]
+	if len(valuesLeft) == 0 || len(valuesRight) == 0 {
+		return UNKNOWN, nil
+	}
+
 	for _, valLeft := range valuesLeft {
 		for _, valRight := range valuesRight {
 			var isTrue bool
@@ -94,27 +132,29 @@ func (c *Comparison) twoAttributesCompare(attributeLeft, attributeRight model.Co
 			c.parameters.left.value = valLeft
 			c.parameters.right.value = valRight
 			if isTrue, err = c.valueComparator(); isTrue && err == nil {
-				return true, nil
+				return TRUE, nil
 			}
 
 			if err != nil {
-				return false, err
+				return FALSE, err
 			}
 		}
 	}
 
-	return false, nil
+	return FALSE, nil
 }
 
-func (c *Comparison) oneAttributeCompare(attribute model.ComplexAttribute) (bool, error) {
+// oneAttributeCompare compares the values of an attribute, put in place of
+// the operand it stands for, with the constant on the other side.
+func (c *Comparison) oneAttributeCompare(attribute model.ComplexAttribute, operand *IdentifierExpression) (TruthValue, error) {
 	relation, err := c.repository.GetRow(attribute.Relation)
 	if err != nil {
 		err.(*entity.CustomError).Position = c.parameters.position
-		return false, err
+		return FALSE, err
 	}
 
 	if c.incorrectAttribute(relation, attribute.Attribute) {
-		return false, &entity.CustomError{
+		return FALSE, &entity.CustomError{
 			ErrorType: entity.ResponseTypes["CE"],
 			Message:   fmt.Sprintf("incorrect attribute %s", attribute.Attribute),
 			Position:  c.parameters.position,
@@ -122,15 +162,19 @@ func (c *Comparison) oneAttributeCompare(attribute model.ComplexAttribute) (bool
 	}
 
 	values := (*relation)[attribute.Attribute]
+	if len(values) == 0 {
+		return UNKNOWN, nil
+	}
+
 	for _, value := range values {
-		c.parameters.left.value = value
+		operand.value = value
 		if isTrue, err := c.valueComparator(); isTrue && err == nil {
-			return true, nil
+			return TRUE, nil
 		} else if err != nil {
-			return false, err
+			return FALSE, err
 		}
 	}
-	return false, nil
+	return FALSE, nil
 }
 
 func (*Comparison) incorrectAttribute(row *entity.RowMap, attribute string) bool {
@@ -194,29 +238,26 @@ func (c *Comparison) numericComparator() (bool, error) {
 }
 
 func (c *Comparison) dateComparator() (bool, error) {
-	oldVal, err := time.Parse(time.DateTime, c.parameters.left.value)
-	if err != nil {
-		return false, nil
-	}
-
-	newVal, err := time.Parse(time.DateTime, c.parameters.right.value)
-	if err != nil {
-		return false, nil
+	oldVal, _ := entity.ParseTemporal(c.parameters.left.value)
+	newVal, _ := entity.ParseTemporal(c.parameters.right.value)
+	comparison, comparable := oldVal.Compare(newVal)
+	if !comparable {
+		return c.stringComparator()
 	}
 
 	switch c.parameters.kind {
 	case "=":
-		return oldVal.Equal(newVal), nil
+		return comparison == 0, nil
 	case "!=":
-		return !oldVal.Equal(newVal), nil
+		return comparison != 0, nil
 	case "<=":
-		return oldVal.Before(newVal) || oldVal.Equal(newVal), nil
+		return comparison <= 0, nil
 	case ">=":
-		return oldVal.After(newVal) || oldVal.Equal(newVal), nil
+		return comparison >= 0, nil
 	case "<":
-		return oldVal.Before(newVal), nil
+		return comparison < 0, nil
 	case ">":
-		return oldVal.After(newVal), nil
+		return comparison > 0, nil
 	default:
 		return false, &entity.CustomError{
 			ErrorType: entity.ResponseTypes["CE"],
@@ -251,6 +292,58 @@ func (c *Comparison) stringComparator() (bool, error) {
 	}
 }
 
+func compareValues(left, right string) int {
+	if isANumber(left) && isANumber(right) {
+		leftNumber, _ := strconv.ParseFloat(left, 10)
+		rightNumber, _ := strconv.ParseFloat(right, 10)
+		return cmp.Compare(leftNumber, rightNumber)
+	}
+
+	if leftDate, isLeftDate := entity.ParseTemporal(left); isLeftDate {
+		if rightDate, isRightDate := entity.ParseTemporal(right); isRightDate {
+			if comparison, comparable := leftDate.Compare(rightDate); comparable {
+				return comparison
+			}
+		}
+	}
+
+	return strings.Compare(left, right)
+}
+
+func compareValueLists(left, right []string) int {
+	for index := 0; index < len(left) && index < len(right); index++ {
+		if comparison := compareValues(left[index], right[index]); comparison != 0 {
+			return comparison
+		}
+	}
+
+	return cmp.Compare(len(left), len(right))
+}
+
+// compareRows orders rows by all of their attributes, so that rows equal on
+// every sort key still come out in a reproducible order.
+func compareRows(left, right *entity.RowMap) int {
+	keys := make([]string, 0, len(*left)+len(*right))
+	for key := range *left {
+		keys = append(keys, key)
+	}
+
+	for key := range *right {
+		if _, exists := (*left)[key]; !exists {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+	for _, key := range keys {
+		if comparison := compareValueLists((*left)[key], (*right)[key]); comparison != 0 {
+			return comparison
+		}
+	}
+
+	return 0
+}
+
 func isANumber(value string) bool {
 	if _, err := strconv.ParseFloat(value, 10); err != nil {
 		return false
@@ -259,8 +352,6 @@ func isANumber(value string) bool {
 }
 
 func isADate(value string) bool {
-	if _, err := time.Parse(time.DateTime, value); err != nil {
-		return false
-	}
-	return true
+	_, isDate := entity.ParseTemporal(value)
+	return isDate
 }
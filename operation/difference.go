@@ -0,0 +1,47 @@
+package operation
+
+import (
+	"alpha-executor/entity"
+	"encoding/json"
+)
+
+// Difference is the set difference of the algebra executor. The interpreter
+// evaluates negation tuple by tuple and does not use it.
+type Difference struct {
+}
+
+// Execute returns the rows of relation1 that are not rows of relation2.
+func (*Difference) Execute(relation1, relation2 *entity.Relation, position entity.Position) (*entity.Relation, error) {
+	if err := relation1.EqualArity(relation2, position); err != nil {
+		return nil, err
+	}
+
+	subtracted := make(map[string]bool, len(*relation2))
+	for row2 := range *relation2 {
+		subtracted[rowKey(row2)] = true
+	}
+
+	difference := make(entity.Relation)
+	for row1 := range *relation1 {
+		if !subtracted[rowKey(row1)] {
+			difference[row1] = struct{}{}
+		}
+	}
+	return &difference, nil
+}
+
+// rowKey is a canonical encoding of a row, equal for rows that RowsEqual
+// considers equal: encoding/json sorts map keys, and NULL is always [].
+func rowKey(row *entity.RowMap) string {
+	normalized := make(entity.RowMap, len(*row))
+	for key, values := range *row {
+		if values == nil {
+			values = []string{}
+		}
+
+		normalized[key] = values
+	}
+
+	key, _ := json.Marshal(normalized)
+	return string(key)
+}
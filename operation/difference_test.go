@@ -0,0 +1,36 @@
+package operation
+
+import (
+	"alpha-executor/entity"
+	"testing"
+)
+
+func TestDifference(t *testing.T) {
+	tests := []struct {
+		name     string
+		left     string
+		right    string
+		expected string
+	}{
+		{"common rows", `[{"a": ["1"]}, {"a": ["2"]}, {"a": ["3"]}]`, `[{"a": ["2"]}, {"a": ["4"]}]`, `[{"a": ["1"]}, {"a": ["3"]}]`},
+		{"empty right operand", `[{"a": ["1"]}]`, `[]`, `[{"a": ["1"]}]`},
+		{"NULL equals NULL", `[{"a": []}, {"a": ["1"]}]`, `[{"a": null}]`, `[{"a": ["1"]}]`},
+		{"multi-valued attributes", `[{"a": ["1", "2"]}, {"a": ["2", "1"]}]`, `[{"a": ["1", "2"]}]`, `[{"a": ["2", "1"]}]`},
+	}
+
+	for _, test := range tests {
+		difference := Difference{}
+		result, err := difference.Execute(pair(t, "R", test.left).Right, pair(t, "S", test.right).Right, entity.Position{})
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+
+		expectRows(t, result, test.expected)
+	}
+}
+
+func TestDifferenceOfIncompatibleRelations(t *testing.T) {
+	difference := Difference{}
+	_, err := difference.Execute(pair(t, "R", `[{"a": ["1"]}]`).Right, pair(t, "S", `[{"b": ["1"]}]`).Right, entity.Position{Line: 2, Column: 3})
+	expectError(t, err, "Incorrect arity at 2:3")
+}
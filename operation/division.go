@@ -0,0 +1,111 @@
+package operation
+
+import (
+	"alpha-executor/entity"
+	"fmt"
+)
+
+// Division is the ÷ operator of the algebra executor, which the reducer also
+// uses for universal quantifiers. The interpreter evaluates ∀ tuple by tuple
+// and does not use it.
+type Division struct {
+}
+
+// Execute returns the rows t over the attributes of dividend that are not
+// attributes of divisor such that t combined with every row of divisor is a
+// row of dividend. The attributes of divisor must be attributes of dividend.
+func (d *Division) Execute(dividend, divisor *entity.Relation, position entity.Position) (*entity.Relation, error) {
+	return d.ExecuteOver(dividend, divisor, nil, position)
+}
+
+// ExecuteOver is Execute for a divisor with the given attributes besides
+// those of its rows. Relations carry their attributes in their rows only, so
+// an empty divisor needs them: dividing by it keeps every row of dividend,
+// projected on the other attributes. Without them, dividing a non-empty
+// relation by an empty one is an error.
+func (d *Division) ExecuteOver(dividend, divisor *entity.Relation, attributes []string, position entity.Position) (*entity.Relation, error) {
+	divisorAttributes := make(map[string]bool)
+	for _, attribute := range attributes {
+		divisorAttributes[attribute] = true
+	}
+
+	for row := range *divisor {
+		for key := range *row {
+			divisorAttributes[key] = true
+		}
+	}
+
+	if len(divisorAttributes) == 0 && len(*dividend) > 0 {
+		return nil, &entity.CustomError{
+			ErrorType: entity.ResponseTypes["RT"],
+			Message:   fmt.Sprintf("Unknown attributes of the empty divisor at %d:%d", position.Line, position.Column),
+			Position:  position,
+		}
+	}
+
+	if err := d.checkArity(dividend, divisor, divisorAttributes, position); err != nil {
+		return nil, err
+	}
+
+	required := make(map[string]bool, len(*divisor))
+	for row := range *divisor {
+		required[rowKey(row)] = true
+	}
+
+	quotients := make(map[string]*entity.RowMap)
+	matched := make(map[string]map[string]bool)
+	for row := range *dividend {
+		quotient, remainder := make(entity.RowMap), make(entity.RowMap)
+		for key, values := range *row {
+			if divisorAttributes[key] {
+				remainder[key] = values
+			} else {
+				quotient[key] = values
+			}
+		}
+
+		key := rowKey(&quotient)
+		if _, exists := quotients[key]; !exists {
+			quotients[key] = &quotient
+			matched[key] = make(map[string]bool)
+		}
+
+		if remainderKey := rowKey(&remainder); required[remainderKey] {
+			matched[key][remainderKey] = true
+		}
+	}
+
+	division := make(entity.Relation)
+	for key, quotient := range quotients {
+		if len(matched[key]) == len(required) {
+			division[quotient] = struct{}{}
+		}
+	}
+	return &division, nil
+}
+
+// checkArity requires every row of divisor to have the same attributes and
+// every row of dividend to have all of them.
+func (*Division) checkArity(dividend, divisor *entity.Relation, divisorAttributes map[string]bool, position entity.Position) error {
+	err := &entity.CustomError{
+		ErrorType: entity.ResponseTypes["RT"],
+		Message:   fmt.Sprintf("Incorrect arity at %d:%d", position.Line, position.Column),
+		Position:  position,
+	}
+
+	for row := range *divisor {
+		if len(*row) != len(divisorAttributes) {
+			return err
+		}
+	}
+
+	for row := range *dividend {
+		for key := range divisorAttributes {
+			if _, exists := (*row)[key]; !exists {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
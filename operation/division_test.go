@@ -0,0 +1,81 @@
+package operation
+
+import (
+	"alpha-executor/entity"
+	"testing"
+)
+
+const enrolments = `[
+	{"student": ["ann"], "course": ["db"]},
+	{"student": ["ann"], "course": ["os"]},
+	{"student": ["bob"], "course": ["db"]},
+	{"student": ["cid"], "course": ["os"]},
+	{"student": ["cid"], "course": ["db"]},
+	{"student": ["cid"], "course": ["ai"]}
+]`
+
+func TestDivision(t *testing.T) {
+	tests := []struct {
+		name     string
+		dividend string
+		divisor  string
+		expected string
+	}{
+		{"every course", enrolments, `[{"course": ["db"]}, {"course": ["os"]}]`, `[{"student": ["ann"]}, {"student": ["cid"]}]`},
+		{"one course", enrolments, `[{"course": ["ai"]}]`, `[{"student": ["cid"]}]`},
+		{"course nobody takes", enrolments, `[{"course": ["ml"]}]`, `[]`},
+		{"empty dividend", `[]`, `[{"course": ["db"]}]`, `[]`},
+		{"empty dividend and divisor", `[]`, `[]`, `[]`},
+	}
+
+	for _, test := range tests {
+		division := Division{}
+		result, err := division.Execute(pair(t, "R", test.dividend).Right, pair(t, "S", test.divisor).Right, entity.Position{})
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+
+		expectRows(t, result, test.expected)
+	}
+}
+
+func TestDivisionErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		divisor string
+		message string
+	}{
+		{"empty divisor of unknown attributes", `[]`, "Unknown attributes of the empty divisor at 1:5"},
+		{"attribute not in dividend", `[{"grade": ["A"]}]`, "Incorrect arity at 1:5"},
+		{"rows over different attributes", `[{"course": ["db"]}, {"course": ["os"], "student": ["ann"]}]`, "Incorrect arity at 1:5"},
+	}
+
+	for _, test := range tests {
+		division := Division{}
+		_, err := division.Execute(pair(t, "R", enrolments).Right, pair(t, "S", test.divisor).Right, entity.Position{Line: 1, Column: 5})
+		expectError(t, err, test.message)
+	}
+}
+
+func TestDivisionByEmptyRelation(t *testing.T) {
+	tests := []struct {
+		name       string
+		dividend   string
+		attributes []string
+		expected   string
+	}{
+		{"every quotient", enrolments, []string{"course"}, `[{"student": ["ann"]}, {"student": ["bob"]}, {"student": ["cid"]}]`},
+		{"all attributes", enrolments, []string{"course", "student"}, `[{}]`},
+		{"empty dividend", `[]`, []string{"course"}, `[]`},
+	}
+
+	for _, test := range tests {
+		division := Division{}
+		result, err := division.ExecuteOver(pair(t, "R", test.dividend).Right, &entity.Relation{}, test.attributes, entity.Position{})
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+
+		expectRows(t, result, test.expected)
+	}
+}
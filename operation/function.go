@@ -0,0 +1,238 @@
+package operation
+
+import (
+	"alpha-executor/entity"
+	"alpha-executor/model"
+	"fmt"
+	"strconv"
+)
+
+func (i *Interpreter) evaluateOperand(expression Expression) ([]string, error) {
+	switch operand := expression.(type) {
+	case *IdentifierExpression:
+		if operand.kind == model.NULL.String() {
+			return nil, nil
+		} else if operand.kind != model.ATTRIBUTE.String() {
+			return []string{operand.value}, nil
+		}
+
+		attr := model.Attribute{}
+		attribute, err := attr.ExtractAttribute(operand.value, operand.position)
+		if err != nil {
+			return nil, err
+		}
+
+		row, err := i.repository.GetRow(attribute.Relation)
+		if err != nil {
+			err.(*entity.CustomError).Position = operand.position
+			return nil, err
+		}
+
+		values, exists := (*row)[attribute.Attribute]
+		if !exists {
+			return nil, &entity.CustomError{
+				ErrorType: entity.ResponseTypes["CE"],
+				Message:   fmt.Sprintf("incorrect attribute %s", attribute.Attribute),
+				Position:  operand.position,
+			}
+		}
+
+		return values, nil
+	case *FunctionExpression:
+		return i.evaluateFunction(operand)
+	case *BinaryExpression:
+		if isArithmetic(operand.kind) {
+			return i.evaluateArithmetic(operand)
+		}
+	case *UnaryExpression:
+		if operand.kind == model.UNARY_MINUS.String() {
+			return i.evaluateUnaryMinus(operand)
+		}
+	}
+
+	return nil, &entity.CustomError{
+		ErrorType: entity.ResponseTypes["CE"],
+		Message:   fmt.Sprintf("Unexpected operand %s", expression.GetKind()),
+	}
+}
+
+func (i *Interpreter) evaluateFunction(expression *FunctionExpression) ([]string, error) {
+	argument := expression.argument.(*IdentifierExpression)
+	relationName, attributeName := argument.value, ""
+	if argument.kind == model.ATTRIBUTE.String() {
+		attr := model.Attribute{}
+		attribute, err := attr.ExtractAttribute(argument.value, argument.position)
+		if err != nil {
+			return nil, err
+		}
+
+		relationName, attributeName = attribute.Relation, attribute.Attribute
+	} else if expression.kind != model.COUNT.String() {
+		return nil, &entity.CustomError{
+			ErrorType: entity.ResponseTypes["CE"],
+			Message:   fmt.Sprintf("%s expects an attribute", expression.kind),
+			Position:  expression.position,
+		}
+	}
+
+	relation, err := i.repository.GetRelation(relationName)
+	if err != nil {
+		err.(*entity.CustomError).Position = argument.position
+		return nil, err
+	}
+
+	previous, _ := i.repository.GetRow(relationName)
+	defer i.restoreRow(relationName, previous)
+
+	count := 0
+	values := make([]string, 0)
+	for row := range *relation {
+		i.repository.AddRow(relationName, row)
+		result, err := i.evaluateQualification(expression.qualification)
+		if err != nil {
+			return nil, err
+		}
+
+		if result != TRUE {
+			continue
+		}
+
+		if attributeName == "" {
+			count++
+			continue
+		}
+
+		rowValues, exists := (*row)[attributeName]
+		if !exists {
+			return nil, &entity.CustomError{
+				ErrorType: entity.ResponseTypes["CE"],
+				Message:   fmt.Sprintf("incorrect attribute %s", attributeName),
+				Position:  argument.position,
+			}
+		}
+
+		if len(rowValues) > 0 {
+			count++
+		}
+
+		values = append(values, rowValues...)
+	}
+
+	return i.aggregate(expression, count, values)
+}
+
+// restoreRow binds a variable again to the row it was bound to before a
+// function or quantifier ranged over it.
+func (i *Interpreter) restoreRow(relationName string, previous *entity.RowMap) {
+	if previous != nil {
+		i.repository.AddRow(relationName, previous)
+	} else {
+		i.repository.DeleteRow(relationName)
+	}
+}
+
+func (i *Interpreter) aggregate(expression *FunctionExpression, count int, values []string) ([]string, error) {
+	switch expression.kind {
+	case model.COUNT.String():
+		return []string{strconv.Itoa(count)}, nil
+	case model.TOTAL.String(), model.AVG.String():
+		total := 0.0
+		for _, value := range values {
+			number, err := strconv.ParseFloat(value, 10)
+			if err != nil {
+				return nil, &entity.CustomError{
+					ErrorType: entity.ResponseTypes["RT"],
+					Message:   fmt.Sprintf("%s expects numeric values, got %s", expression.kind, value),
+					Position:  expression.position,
+				}
+			}
+
+			total += number
+		}
+
+		if expression.kind == model.TOTAL.String() {
+			return []string{strconv.FormatFloat(total, 'f', -1, 64)}, nil
+		}
+
+		if len(values) == 0 {
+			return []string{}, nil
+		}
+
+		return []string{strconv.FormatFloat(total/float64(len(values)), 'f', -1, 64)}, nil
+	case model.MAX.String(), model.MIN.String():
+		if len(values) == 0 {
+			return []string{}, nil
+		}
+
+		result := values[0]
+		for _, value := range values[1:] {
+			comparison := compareValues(value, result)
+			if (expression.kind == model.MAX.String() && comparison > 0) ||
+				(expression.kind == model.MIN.String() && comparison < 0) {
+				result = value
+			}
+		}
+
+		return []string{result}, nil
+	default:
+		return nil, &entity.CustomError{
+			ErrorType: entity.ResponseTypes["CE"],
+			Message:   fmt.Sprintf("Unknown function %s", expression.kind),
+			Position:  expression.position,
+		}
+	}
+}
+
+func (i *Interpreter) evaluateColumns(
+	relations []string,
+	relation *entity.Relation,
+	columns []entity.Pair[string, Expression],
+) (*entity.Relation, error) {
+	if len(columns) == 0 {
+		return relation, nil
+	}
+
+	computed := make(entity.Relation)
+	for row := range *relation {
+		newRow := row.Copy()
+		for _, relationName := range relations {
+			i.repository.AddRow(relationName, newRow)
+		}
+
+		for _, column := range columns {
+			values, err := i.evaluateOperand(column.Right)
+			if err != nil {
+				return nil, err
+			}
+
+			(*newRow)[column.Left] = values
+		}
+
+		computed[newRow] = struct{}{}
+	}
+
+	return &computed, nil
+}
+
+func columnName(expression Expression) string {
+	switch column := expression.(type) {
+	case *FunctionExpression:
+		return fmt.Sprintf("%s(%s)", column.kind, column.argument.(*IdentifierExpression).value)
+	case *IdentifierExpression:
+		return column.value
+	case *BinaryExpression:
+		return fmt.Sprintf("%s %s %s", operandName(column.left), column.kind, operandName(column.right))
+	case *UnaryExpression:
+		return fmt.Sprintf("-%s", operandName(column.expression))
+	default:
+		return expression.GetKind()
+	}
+}
+
+func operandName(expression Expression) string {
+	if _, isBinary := expression.(*BinaryExpression); isBinary {
+		return fmt.Sprintf("(%s)", columnName(expression))
+	}
+
+	return columnName(expression)
+}
@@ -0,0 +1,35 @@
+package operation
+
+import "testing"
+
+func TestAggregatesInTargetList(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{`GET W (COUNT(EMP))`, `[{"COUNT(EMP)": ["4"]}]`},
+		{`GET W (TOTAL(EMP.salary))`, `[{"TOTAL(EMP.salary)": ["740"]}]`},
+		{`GET W (MAX(EMP.salary), MIN(EMP.salary))`, `[{"MAX(EMP.salary)": ["300"], "MIN(EMP.salary)": ["90"]}]`},
+		{`GET W (AVG(EMP.salary))`, `[{"AVG(EMP.salary)": ["185"]}]`},
+		{`GET W (COUNT(EMP WHERE EMP.dept = "d1"))`, `[{"COUNT(EMP)": ["2"]}]`},
+		{
+			`RANGE EMP E; GET W (DEPT.dept, COUNT(E WHERE E.dept = DEPT.dept))`,
+			`[{"dept": ["d1"], "COUNT(E)": ["2"]}, {"dept": ["d2"], "COUNT(E)": ["1"]}, {"dept": ["d3"], "COUNT(E)": ["1"]}]`,
+		},
+	}
+
+	for _, test := range tests {
+		expectWorkspace(t, employees, test.query, "W", test.expected)
+	}
+}
+
+func TestAggregatesInQualification(t *testing.T) {
+	expectWorkspace(t, employees, `RANGE EMP E; GET W (DEPT.title): COUNT(E WHERE E.dept = DEPT.dept) > 1`, "W", `[{"title": ["sales"]}]`)
+	expectWorkspace(t, employees, `GET W (EMP.id): EMP.salary > AVG(EMP.salary)`, "W", `[{"id": ["2"]}, {"id": ["4"]}]`)
+}
+
+func TestAggregatesSkipNulls(t *testing.T) {
+	data := `{"EMP": [{"id": ["1"], "salary": ["100"]}, {"id": ["2"], "salary": null}, {"id": ["3"], "salary": ["50"]}]}`
+	expectWorkspace(t, data, `GET W (COUNT(EMP), COUNT(EMP.salary), AVG(EMP.salary))`, "W",
+		`[{"COUNT(EMP)": ["3"], "COUNT(EMP.salary)": ["2"], "AVG(EMP.salary)": ["75"]}]`)
+}
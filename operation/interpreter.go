@@ -5,8 +5,6 @@ import (
 	"alpha-executor/model"
 	"alpha-executor/repository"
 	"fmt"
-	"github.com/kr/pretty"
-	"log"
 	"slices"
 	"sort"
 	"strconv"
@@ -14,11 +12,13 @@ import (
 
 type Interpreter struct {
 	repository *repository.AlphaRepository
+	optimizer  *Optimizer
 }
 
 func NewInterpreter(repository *repository.AlphaRepository) *Interpreter {
 	return &Interpreter{
 		repository: repository,
+		optimizer:  NewOptimizer(),
 	}
 }
 
@@ -63,6 +63,30 @@ func (i *Interpreter) evaluateExpression(expression Expression) (bool, error) {
 	switch expression.GetKind() {
 	case model.GET.String(), model.HOLD.String():
 		return i.evaluateGet(expression.(*GetHoldExpression), expression.GetKind())
+	case model.RANGE.String():
+		return i.evaluateRange(expression.(*RangeExpression))
+	case model.ASSIGN.String():
+		return i.evaluateAssignment(expression.(*BinaryExpression))
+	case model.UPDATE.String():
+		return i.evaluateUpdate(expression.(*UnaryExpression))
+	case model.RELEASE.String():
+		return i.evaluateRelease(expression.(*UnaryExpression))
+	case model.DELETE.String():
+		return i.evaluateDelete(expression.(*UnaryExpression))
+	case model.PUT.String():
+		return i.evaluatePut(expression.(*PutExpression))
+	default:
+		return false, &entity.CustomError{
+			ErrorType: entity.ResponseTypes["RT"],
+			Message:   fmt.Sprintf("Unknown kind %s", expression.GetKind()),
+		}
+	}
+}
+
+// evaluateCondition evaluates a qualification for the rows currently bound
+// in the repository.
+func (i *Interpreter) evaluateCondition(expression Expression) (TruthValue, error) {
+	switch expression.GetKind() {
 	case model.EQUALS.String(),
 		model.NOT_EQUALS.String(),
 		model.LESS_THAN_EQUALS.String(),
@@ -70,8 +94,14 @@ func (i *Interpreter) evaluateExpression(expression Expression) (bool, error) {
 		model.LESS_THAN.String(),
 		model.GREATER_THAN.String():
 		return i.evaluateComparison(expression.(*BinaryExpression))
-	case model.RANGE.String():
-		return i.evaluateRange(expression.(*RangeExpression))
+	case model.IS_NULL.String(), model.IS_NOT_NULL.String():
+		return i.evaluateNullTest(expression.(*UnaryExpression))
+	case model.IN.String():
+		return i.evaluateIn(expression.(*InExpression))
+	case model.BETWEEN.String():
+		return i.evaluateBetween(expression.(*BetweenExpression))
+	case model.LIKE.String(), model.MATCH.String():
+		return i.evaluatePattern(expression.(*PatternExpression))
 	case model.CONJUNCTION.String():
 		return i.evaluateConjunction(expression.(*BinaryExpression))
 	case model.DISJUNCTION.String():
@@ -84,71 +114,155 @@ func (i *Interpreter) evaluateExpression(expression Expression) (bool, error) {
 		return i.evaluateNegation(expression.(*UnaryExpression))
 	case model.IMPLICATION.String():
 		return i.evaluateImplication(expression.(*BinaryExpression))
-	case model.ASSIGN.String():
-		return i.evaluateAssignment(expression.(*BinaryExpression))
-	case model.UPDATE.String():
-		return i.evaluateUpdate(expression.(*UnaryExpression))
-	case model.RELEASE.String():
-		return i.evaluateRelease(expression.(*UnaryExpression))
 	default:
-		return false, &entity.CustomError{
+		return FALSE, &entity.CustomError{
 			ErrorType: entity.ResponseTypes["RT"],
 			Message:   fmt.Sprintf("Unknown kind %s", expression.GetKind()),
 		}
 	}
 }
 
+// evaluateFreeRelation finds the rows of every relation of the target list
+// that take part in some combination of rows satisfying the qualification,
+// following the plan of the optimizer.
 func (i *Interpreter) evaluateFreeRelation(
 	relations []string,
 	expression Expression,
 	resultRelations *entity.Relations,
 ) (bool, error) {
-	relationName := relations[0]
-	relations = relations[1:]
+	if len(relations) == 0 {
+		result, err := i.evaluateQualification(expression)
+		return result == TRUE, err
+	}
+
+	plan := i.optimizer.Plan(relations, expression)
+	for _, conjunct := range plan.closed {
+		truth, err := i.evaluateQualification(conjunct)
+		if err != nil || truth != TRUE {
+			return i.noCombination(relations, resultRelations), err
+		}
+	}
+
+	candidates := make(map[string][]*entity.RowMap, len(relations))
+	sizes := make(map[string]int, len(relations))
+	for _, relationName := range relations {
+		rows, err := i.filterRows(relationName, plan.filters[relationName])
+		if err != nil || len(rows) == 0 {
+			return i.noCombination(relations, resultRelations), err
+		}
+
+		candidates[relationName] = rows
+		sizes[relationName] = len(rows)
+	}
+
+	i.optimizer.Order(plan, sizes)
+	for _, group := range plan.groups {
+		selected := make(map[string]map[*entity.RowMap]bool, len(group.order))
+		for _, relationName := range group.order {
+			selected[relationName] = make(map[*entity.RowMap]bool)
+		}
+
+		path := make([]*entity.RowMap, 0, len(group.order))
+		if err := i.searchCombinations(group, candidates, path, selected); err != nil {
+			return false, err
+		}
+
+		if len(selected[group.order[0]]) == 0 {
+			return i.noCombination(relations, resultRelations), nil
+		}
+
+		for relationName, rows := range selected {
+			newRelation := make(entity.Relation, len(rows))
+			for row := range rows {
+				newRelation[row.Copy()] = struct{}{}
+			}
+
+			(*resultRelations)[relationName] = &newRelation
+		}
+	}
+
+	return true, nil
+}
+
+// filterRows returns the rows of a relation for which all conjuncts pushed
+// down to it are TRUE.
+func (i *Interpreter) filterRows(relationName string, filters []Expression) ([]*entity.RowMap, error) {
 	relation, err := i.repository.GetRelation(relationName)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	newRelation := make(entity.Relation)
+	rows := make([]*entity.RowMap, 0, len(*relation))
 	for row := range *relation {
-		result := false
-		rowCopy := *row
-		i.repository.AddRow(relationName, &rowCopy)
+		i.repository.AddRow(relationName, row)
+		accepted, err := i.evaluateConjuncts(filters)
+		if err != nil {
+			return nil, err
+		}
 
-		if len(relations) == 0 {
-			result, err = i.evaluateExpression(expression)
-			if err != nil {
-				return false, err
-			}
+		if accepted {
+			rows = append(rows, row)
 		}
+	}
 
-		if len(relations) > 0 {
-			result, err = i.evaluateFreeRelation(relations, expression, resultRelations)
-			if err != nil {
-				return false, err
-			}
+	return rows, nil
+}
+
+// searchCombinations extends the rows bound to the variables of a group
+// before path by every candidate row of the next variable that satisfies
+// the conjuncts checked there, and marks the rows of complete combinations.
+func (i *Interpreter) searchCombinations(
+	group *JoinGroup,
+	candidates map[string][]*entity.RowMap,
+	path []*entity.RowMap,
+	selected map[string]map[*entity.RowMap]bool,
+) error {
+	depth := len(path)
+	if depth == len(group.order) {
+		for k, row := range path {
+			selected[group.order[k]][row] = true
 		}
+		return nil
+	}
+
+	relationName := group.order[depth]
+	for _, row := range candidates[relationName] {
+		i.repository.AddRow(relationName, row)
 
-		if result {
-			newRelation[&rowCopy] = struct{}{}
+		accepted, err := i.evaluateConjuncts(group.checks[depth])
+		if err != nil {
+			return err
+		}
+
+		if accepted {
+			if err = i.searchCombinations(group, candidates, append(path, row), selected); err != nil {
+				return err
+			}
 		}
 	}
 
-	if _, exists := (*resultRelations)[relationName]; exists {
-		currentRelation := (*resultRelations)[relationName]
-		for row := range newRelation {
-			(*currentRelation)[row] = struct{}{}
+	return nil
+}
+
+func (i *Interpreter) evaluateConjuncts(conjuncts []Expression) (bool, error) {
+	for _, conjunct := range conjuncts {
+		truth, err := i.evaluateQualification(conjunct)
+		if err != nil || truth != TRUE {
+			return false, err
 		}
-	} else {
-		(*resultRelations)[relationName] = &newRelation
 	}
 
-	if len(newRelation) > 0 {
-		return true, nil
+	return true, nil
+}
+
+// noCombination records that no combination of rows satisfies the
+// qualification.
+func (i *Interpreter) noCombination(relations []string, resultRelations *entity.Relations) bool {
+	for _, relationName := range relations {
+		(*resultRelations)[relationName] = &entity.Relation{}
 	}
 
-	return false, nil
+	return false
 }
 
 func (i *Interpreter) evaluateGet(expression *GetHoldExpression, operation string) (bool, error) {
@@ -161,7 +275,7 @@ func (i *Interpreter) evaluateGet(expression *GetHoldExpression, operation strin
 		}
 	}
 
-	relations, attributes, isRelation, err := i.getData(expression, relation)
+	relations, attributes, columns, isRelation, err := i.getData(expression, relation)
 	if err != nil {
 		return false, err
 	}
@@ -169,36 +283,49 @@ func (i *Interpreter) evaluateGet(expression *GetHoldExpression, operation strin
 	resultRelations := make(entity.Relations)
 	evaluationResult, err := i.evaluateFreeRelation(relations, expression.expression, &resultRelations)
 	if err != nil {
-		err.(*entity.CustomError).Position = expression.position
+		if err.(*entity.CustomError).Position == (entity.Position{}) {
+			err.(*entity.CustomError).Position = expression.position
+		}
 		return false, err
 	}
 
 	i.repository.AddCalculatedRelations(resultRelations)
 
-	sortExpression := expression.sort.(*UnaryExpression)
-	sorted := make([]*entity.RowMap, 0)
+	sortExpression := expression.sort
+	var sorted []*entity.RowMap
 
 	var result *entity.Relation
 	if !isRelation && evaluationResult {
-		rel, err := i.joiningRelations(relations)
+		rel, err := i.joiningRelations(i.optimizer.JoinOrder(relations, resultRelations))
 		if err != nil {
 			return false, err
 		}
 
+		if rel, err = i.evaluateColumns(relations, rel, columns); err != nil {
+			return false, err
+		}
+
 		sorted, err = i.evaluateSort(sortExpression, rel)
 		if err != nil {
 			return false, err
 		}
 
 		projection := Projection{}
-		relationPair := entity.Pair[string, *entity.Relation]{Left: relation.value, Right: rel}
-		result, err = projection.Execute(relationPair, attributes, relation.position)
-		if err != nil {
-			return false, err
+		if sorted != nil {
+			if sorted, err = projection.ExecuteOrdered(sorted, attributes, relation.position); err != nil {
+				return false, err
+			}
+
+			result = i.sliceToMap(sorted)
+		} else {
+			relationPair := entity.Pair[string, *entity.Relation]{Left: relation.value, Right: rel}
+			result, err = projection.Execute(relationPair, attributes, relation.position)
+			if err != nil {
+				return false, err
+			}
 		}
 	} else if isRelation && evaluationResult {
-		result, err = i.repository.GetRelation(relations[0])
-		if err != nil {
+		if result, err = i.evaluateColumns(relations, resultRelations[relations[0]], columns); err != nil {
 			return false, err
 		}
 
@@ -207,23 +334,23 @@ func (i *Interpreter) evaluateGet(expression *GetHoldExpression, operation strin
 			return false, err
 		}
 	} else {
-		if err = i.addToRepository(expression, operation, relation.value, result); err != nil {
+		empty := make(entity.Relation)
+		if len(sortExpression) > 0 {
+			sorted = make([]*entity.RowMap, 0)
+		}
+
+		if err = i.addToRepository(expression, operation, relation.value, relations, &empty, sorted); err != nil {
 			return false, err
 		}
 
 		return true, nil
 	}
 
-	if _, err = pretty.Print(sorted); err != nil {
-		return false, err
-	}
-
-	resultRowNum := expression.rows.(*IdentifierExpression).value
-	if err = i.limitResultRows(result, resultRowNum); err != nil {
+	if result, sorted, err = i.limitResultRows(relation.value, result, sorted, expression.rows); err != nil {
 		return false, err
 	}
 
-	if err = i.addToRepository(expression, operation, relation.value, result); err != nil {
+	if err = i.addToRepository(expression, operation, relation.value, relations, result, sorted); err != nil {
 		return false, err
 	}
 
@@ -234,7 +361,9 @@ func (i *Interpreter) addToRepository(
 	expression *GetHoldExpression,
 	operation string,
 	relationName string,
+	relations []string,
 	result *entity.Relation,
+	order []*entity.RowMap,
 ) error {
 	if result == nil {
 		return nil
@@ -242,10 +371,30 @@ func (i *Interpreter) addToRepository(
 
 	switch operation {
 	case model.GET.String():
-		i.repository.AddGetRelation(relationName, result)
+		if _, err := i.repository.GetGetRelation(relationName); err != nil {
+			if _, err = i.repository.GetRelation(relationName); err == nil {
+				return &entity.CustomError{
+					ErrorType: entity.ResponseTypes["CE"],
+					Message:   fmt.Sprintf("workspace %s conflicts with relation %s", relationName, relationName),
+					Position:  expression.variable.(*IdentifierExpression).position,
+				}
+			}
+		}
+
+		// A workspace is also a relation, so later statements of the
+		// program can range over it or refer to its attributes.
+		i.repository.AddGetRelation(relationName, entity.NewWorkspace(result, order))
+		i.repository.AddRelation(relationName, result)
 		break
 	case model.HOLD.String():
-		i.repository.AddHeldRelation(relationName, result)
+		source := ""
+		var tuples []*entity.RowMap
+		if len(relations) == 1 {
+			source = relations[0]
+			tuples = i.heldTuples(source, result)
+		}
+
+		i.repository.AddHeldRelation(relationName, source, result, tuples)
 	default:
 		return &entity.CustomError{
 			ErrorType: entity.ResponseTypes["RT"],
@@ -256,13 +405,44 @@ func (i *Interpreter) addToRepository(
 	return nil
 }
 
-func (i *Interpreter) getData(expression *GetHoldExpression, relation *IdentifierExpression) ([]string, []string, bool, error) {
+// heldTuples returns the qualifying tuples of the source of a held
+// workspace that agree with one of its rows on the attributes they share, so
+// that DELETE removes whole tuples rather than every tuple sharing the held
+// attribute values.
+func (i *Interpreter) heldTuples(source string, held *entity.Relation) []*entity.RowMap {
+	tuples := make([]*entity.RowMap, 0)
+	qualifying, err := i.repository.GetCalculatedRelation(source)
+	if err != nil {
+		return tuples
+	}
+
+	for tuple := range *qualifying {
+		for row := range *held {
+			if tuple.Agrees(row) {
+				tuples = append(tuples, tuple.Copy())
+				break
+			}
+		}
+	}
+
+	return tuples
+}
+
+func (i *Interpreter) getData(
+	expression *GetHoldExpression,
+	relation *IdentifierExpression,
+) ([]string, []string, []entity.Pair[string, Expression], bool, error) {
 	relations := make([]string, 0)
 	attributes := make([]string, 0)
+	columns := make([]entity.Pair[string, Expression], 0)
 	isRelation := false
 	for _, data := range expression.relations {
 		switch data.GetKind() {
 		case model.FREE_RELATION.String():
+			if isRelation {
+				break
+			}
+
 			isRelation = true
 			relations = append(relations, data.(*IdentifierExpression).value)
 			break
@@ -272,7 +452,7 @@ func (i *Interpreter) getData(expression *GetHoldExpression, relation *Identifie
 			attribute, err := attr.ExtractAttribute(assertedData.value, assertedData.position)
 			if err != nil {
 				err.(*entity.CustomError).Position = data.(*IdentifierExpression).position
-				return nil, nil, false, err
+				return nil, nil, nil, false, err
 			}
 
 			if !slices.Contains(relations, attribute.Relation) {
@@ -283,23 +463,51 @@ func (i *Interpreter) getData(expression *GetHoldExpression, relation *Identifie
 				attributes = append(attributes, attribute.Attribute)
 			}
 			break
-		default:
-			return nil, nil, false, &entity.CustomError{
-				ErrorType: entity.ResponseTypes["CE"],
-				Message:   "Unexpected type",
-				Position:  relation.position,
+		case model.COUNT.String(), model.TOTAL.String(), model.MAX.String(), model.MIN.String(), model.AVG.String():
+			name := columnName(data)
+			for _, column := range columns {
+				if column.Left == name {
+					return nil, nil, nil, false, &entity.CustomError{
+						ErrorType: entity.ResponseTypes["CE"],
+						Message:   fmt.Sprintf("Duplicate column %s", name),
+						Position:  data.(*FunctionExpression).position,
+					}
+				}
 			}
-		}
 
-		if isRelation {
+			columns = append(columns, entity.Pair[string, Expression]{Left: name, Right: data})
+			attributes = append(attributes, name)
 			break
+		default:
+			if !isArithmetic(data.GetKind()) {
+				return nil, nil, nil, false, &entity.CustomError{
+					ErrorType: entity.ResponseTypes["CE"],
+					Message:   "Unexpected type",
+					Position:  relation.position,
+				}
+			}
+
+			for _, relationName := range attributeRelations(data) {
+				if !slices.Contains(relations, relationName) {
+					relations = append(relations, relationName)
+				}
+			}
+
+			name := columnName(data)
+			columns = append(columns, entity.Pair[string, Expression]{Left: name, Right: data})
+			attributes = append(attributes, name)
 		}
 	}
 
-	return relations, attributes, isRelation, nil
+	return relations, attributes, columns, isRelation, nil
 }
 
 func (i *Interpreter) joiningRelations(relations []string) (*entity.Relation, error) {
+	if len(relations) == 0 {
+		row := make(entity.RowMap)
+		return &entity.Relation{&row: struct{}{}}, nil
+	}
+
 	join := Join{}
 	for _, rel1Name := range relations {
 		rel1Value, err := i.repository.GetCalculatedRelation(rel1Name)
@@ -317,27 +525,18 @@ func (i *Interpreter) joiningRelations(relations []string) (*entity.Relation, er
 
 			rel2Pair := entity.Pair[string, *entity.Relation]{Left: rel2Name, Right: rel2Value}
 			relations = relations[1:]
+			// The relations are joined on the attributes every tuple of both
+			// of them has, whichever tuples come first.
 			commonAttributes := make([]string, 0)
-			for row1 := range *rel1Pair.Right {
-				for row2 := range *rel2Pair.Right {
-					exists := make(map[string]struct{})
-					for key := range *row1 {
-						exists[key] = struct{}{}
-					}
-
-					for key := range *row2 {
-						if _, ok := exists[key]; ok {
-							commonAttributes = append(commonAttributes, key)
-						}
-					}
-					break
+			for _, key := range attributes(rel1Pair.Right) {
+				if join.hasAttribute(rel1Pair.Right, key) && join.hasAttribute(rel2Pair.Right, key) {
+					commonAttributes = append(commonAttributes, key)
 				}
-				break
 			}
 
 			rel1Pair.Right, err = join.Execute(rel1Pair, rel2Pair, commonAttributes)
 			if err != nil {
-				log.Fatal(err)
+				return nil, err
 			}
 		}
 		return rel1Pair.Right, nil
@@ -359,35 +558,47 @@ func (i *Interpreter) mapToSlice(relation *entity.Relation) []*entity.RowMap {
 	return relationSlice
 }
 
-func (i *Interpreter) limitResultRows(result *entity.Relation, resultRowNum string) error {
-	resultSliced := make(entity.Relation)
-	if resultRowNum != model.NULL.String() {
-		rowNum, err := strconv.Atoi(resultRowNum)
-		if err != nil {
-			return err
-		}
+func (i *Interpreter) sliceToMap(rows []*entity.RowMap) *entity.Relation {
+	relation := make(entity.Relation, len(rows))
+	for _, row := range rows {
+		relation[row] = struct{}{}
+	}
 
-		if rowNum < len(*result) {
-			count := 0
-			for row := range *result {
-				if count >= rowNum {
-					break
-				}
+	return &relation
+}
 
-				resultSliced[row] = struct{}{}
-				count++
-			}
+// limitResultRows keeps the next `rows` tuples of a workspace, taken in
+// sort order, or in canonical order when the query has no sort clause. Each
+// limited GET of the same workspace continues where the previous one stopped.
+func (i *Interpreter) limitResultRows(
+	name string,
+	result *entity.Relation,
+	sorted []*entity.RowMap,
+	rows Expression,
+) (*entity.Relation, []*entity.RowMap, error) {
+	if rows == nil {
+		i.repository.ResetCursor(name)
+		return result, sorted, nil
+	}
 
-			result = &resultSliced
-		}
+	rowNum, err := strconv.Atoi(rows.(*IdentifierExpression).value)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return nil
-}
+	ordered := sorted
+	if ordered == nil {
+		ordered = i.mapToSlice(result)
+		slices.SortFunc(ordered, compareRows)
+	}
 
-func (i *Interpreter) evaluateComparison(expression *BinaryExpression) (bool, error) {
-	comparison := NewComparison(i.repository)
-	return comparison.Compare(expression)
+	offset := min(i.repository.AdvanceCursor(name, rowNum), len(ordered))
+	window := ordered[offset:min(offset+rowNum, len(ordered))]
+	if sorted != nil {
+		sorted = window
+	}
+
+	return i.sliceToMap(window), sorted, nil
 }
 
 func (i *Interpreter) evaluateRange(expression *RangeExpression) (bool, error) {
@@ -400,155 +611,216 @@ func (i *Interpreter) evaluateRange(expression *RangeExpression) (bool, error) {
 	return true, nil
 }
 
-func (i *Interpreter) evaluateConjunction(expression *BinaryExpression) (bool, error) {
-	left, err := i.evaluateExpression(expression.left)
+func (i *Interpreter) evaluateQualification(expression Expression) (TruthValue, error) {
+	if expression == nil {
+		return TRUE, nil
+	}
+
+	return i.evaluateCondition(expression)
+}
+
+func (i *Interpreter) evaluateComparison(expression *BinaryExpression) (TruthValue, error) {
+	comparison := NewComparison(i.repository)
+	left, isLeftIdentifier := expression.left.(*IdentifierExpression)
+	right, isRightIdentifier := expression.right.(*IdentifierExpression)
+	if isLeftIdentifier && isRightIdentifier &&
+		left.kind == model.ATTRIBUTE.String() && right.kind == model.ATTRIBUTE.String() {
+		return comparison.Compare(expression)
+	}
+
+	leftValues, err := i.evaluateOperand(expression.left)
 	if err != nil {
-		return false, err
+		return FALSE, err
 	}
 
-	right, err := i.evaluateExpression(expression.right)
+	rightValues, err := i.evaluateOperand(expression.right)
 	if err != nil {
-		return false, err
+		return FALSE, err
 	}
 
-	return left && right, nil
+	return comparison.CompareValues(expression.kind, leftValues, rightValues, expression.position)
 }
 
-func (i *Interpreter) evaluateDisjunction(expression *BinaryExpression) (bool, error) {
-	left, err := i.evaluateExpression(expression.left)
+func (i *Interpreter) evaluateNullTest(expression *UnaryExpression) (TruthValue, error) {
+	values, err := i.evaluateOperand(expression.expression)
 	if err != nil {
-		return false, err
+		return FALSE, err
 	}
 
-	right, err := i.evaluateExpression(expression.right)
+	isNull := len(values) == 0
+	if expression.kind == model.IS_NOT_NULL.String() {
+		return truthOf(!isNull), nil
+	}
+
+	return truthOf(isNull), nil
+}
+
+func (i *Interpreter) evaluateConjunction(expression *BinaryExpression) (TruthValue, error) {
+	left, err := i.evaluateCondition(expression.left)
 	if err != nil {
-		return false, err
+		return FALSE, err
 	}
 
-	return left || right, nil
+	right, err := i.evaluateCondition(expression.right)
+	if err != nil {
+		return FALSE, err
+	}
+
+	return left.And(right), nil
+}
+
+func (i *Interpreter) evaluateDisjunction(expression *BinaryExpression) (TruthValue, error) {
+	left, err := i.evaluateCondition(expression.left)
+	if err != nil {
+		return FALSE, err
+	}
+
+	right, err := i.evaluateCondition(expression.right)
+	if err != nil {
+		return FALSE, err
+	}
+
+	return left.Or(right), nil
 }
 
-func (i *Interpreter) evaluateExists(expression *BinaryExpression) (bool, error) {
+// evaluateExists is TRUE if the qualification holds for some row, and
+// UNKNOWN if it is unknown for some row but holds for none.
+func (i *Interpreter) evaluateExists(expression *BinaryExpression) (TruthValue, error) {
 	relationName := expression.left.(*IdentifierExpression).value
 	left, err := i.repository.GetRelation(relationName)
 	if err != nil {
-		return false, err
+		return FALSE, err
 	}
 
+	previous, _ := i.repository.GetRow(relationName)
+	defer i.restoreRow(relationName, previous)
+
+	result := FALSE
 	for row := range *left {
 		i.repository.AddRow(relationName, row)
 
-		right, err := i.evaluateExpression(expression.right)
+		right, err := i.evaluateCondition(expression.right)
 		if err != nil {
-			return false, err
+			return FALSE, err
 		}
 
-		if right {
-			return true, nil
+		if right == TRUE {
+			return TRUE, nil
 		}
+
+		result = result.Or(right)
 	}
 
-	return false, nil
+	return result, nil
 }
 
-func (i *Interpreter) evaluateForAll(expression *BinaryExpression) (bool, error) {
+// evaluateForAll is FALSE if the qualification fails for some row, and
+// UNKNOWN if it is unknown for some row but fails for none.
+func (i *Interpreter) evaluateForAll(expression *BinaryExpression) (TruthValue, error) {
 	relationName := expression.left.(*IdentifierExpression).value
 	left, err := i.repository.GetRelation(relationName)
 	if err != nil {
-		return false, err
+		return FALSE, err
 	}
 
+	previous, _ := i.repository.GetRow(relationName)
+	defer i.restoreRow(relationName, previous)
+
+	result := TRUE
 	for row := range *left {
 		i.repository.AddRow(relationName, row)
 
-		right, err := i.evaluateExpression(expression.right)
+		right, err := i.evaluateCondition(expression.right)
 		if err != nil {
-			return false, err
+			return FALSE, err
 		}
 
-		if !right {
-			return false, nil
+		if right == FALSE {
+			return FALSE, nil
 		}
+
+		result = result.And(right)
 	}
 
-	return true, nil
+	return result, nil
 }
 
-func (i *Interpreter) evaluateNegation(expression *UnaryExpression) (bool, error) {
-	calculatedExpression, err := i.evaluateExpression(expression.expression)
+func (i *Interpreter) evaluateNegation(expression *UnaryExpression) (TruthValue, error) {
+	calculatedExpression, err := i.evaluateCondition(expression.expression)
 	if err != nil {
-		return false, err
+		return FALSE, err
 	}
 
-	return !calculatedExpression, nil
+	return calculatedExpression.Not(), nil
 }
 
-func (i *Interpreter) evaluateImplication(expression *BinaryExpression) (bool, error) {
-	left, err := i.evaluateExpression(expression.left)
+func (i *Interpreter) evaluateImplication(expression *BinaryExpression) (TruthValue, error) {
+	left, err := i.evaluateCondition(expression.left)
 	if err != nil {
-		return false, err
+		return FALSE, err
 	}
 
-	right, err := i.evaluateExpression(expression.right)
+	right, err := i.evaluateCondition(expression.right)
 	if err != nil {
-		return false, err
+		return FALSE, err
 	}
 
-	return !left || right, nil
+	return left.Implies(right), nil
 }
 
-func (i *Interpreter) evaluateSort(expression *UnaryExpression, relation *entity.Relation) ([]*entity.RowMap, error) {
-	if expression.kind == model.NULL.String() {
+func (i *Interpreter) evaluateSort(expressions []Expression, relation *entity.Relation) ([]*entity.RowMap, error) {
+	if len(expressions) == 0 {
 		return nil, nil
 	}
 
-	if expression.kind != model.UP.String() && expression.kind != model.DOWN.String() {
-		return nil, &entity.CustomError{
-			ErrorType: entity.ResponseTypes["CE"],
-			Message:   "Such sort type is undefined",
-			Position:  expression.position,
+	keys := make([]entity.Pair[string, string], 0, len(expressions))
+	for _, expression := range expressions {
+		sortExpression := expression.(*UnaryExpression)
+		if sortExpression.kind != model.UP.String() && sortExpression.kind != model.DOWN.String() {
+			return nil, &entity.CustomError{
+				ErrorType: entity.ResponseTypes["CE"],
+				Message:   "Such sort type is undefined",
+				Position:  sortExpression.position,
+			}
 		}
-	}
 
-	attr := model.Attribute{}
-	complexAttribute, err := attr.ExtractAttribute(expression.expression.(*IdentifierExpression).value, expression.position)
-	if err != nil {
-		return nil, err
-	}
+		attr := model.Attribute{}
+		attributeExpression := sortExpression.expression.(*IdentifierExpression)
+		complexAttribute, err := attr.ExtractAttribute(attributeExpression.value, attributeExpression.position)
+		if err != nil {
+			return nil, err
+		}
 
-	attribute := complexAttribute.Attribute
-	for row := range *relation {
-		if _, exists := (*row)[attribute]; !exists {
-			return nil, &entity.CustomError{
-				ErrorType: entity.ResponseTypes["CE"],
-				Message:   fmt.Sprintf("Attribute %s doesn't exist", attribute),
+		attribute := complexAttribute.Attribute
+		for row := range *relation {
+			if _, exists := (*row)[attribute]; !exists {
+				return nil, &entity.CustomError{
+					ErrorType: entity.ResponseTypes["CE"],
+					Message:   fmt.Sprintf("Attribute %s doesn't exist", attribute),
+					Position:  attributeExpression.position,
+				}
 			}
 		}
+
+		keys = append(keys, entity.Pair[string, string]{Left: sortExpression.kind, Right: attribute})
 	}
 
 	relationSlice := i.mapToSlice(relation)
-	sort.Slice(relationSlice, func(i, j int) bool {
-		values1, values2 := (*relationSlice[i])[attribute], (*relationSlice[j])[attribute]
-		for _, value1 := range values1 {
-			for _, value2 := range values2 {
-				switch expression.kind {
-				case model.UP.String():
-					if value1 > value2 {
-						return true
-					} else {
-						return false
-					}
-				case model.DOWN.String():
-					if value1 < value2 {
-						return true
-					} else {
-						return false
-					}
-				}
+	sort.SliceStable(relationSlice, func(a, b int) bool {
+		for _, key := range keys {
+			comparison := compareValueLists((*relationSlice[a])[key.Right], (*relationSlice[b])[key.Right])
+			if comparison == 0 {
+				continue
+			}
+
+			if key.Left == model.DOWN.String() {
+				return comparison > 0
 			}
+
+			return comparison < 0
 		}
 
-		return false
+		return compareRows(relationSlice[a], relationSlice[b]) < 0
 	})
 
 	return relationSlice, nil
@@ -556,7 +828,6 @@ func (i *Interpreter) evaluateSort(expression *UnaryExpression, relation *entity
 
 func (i *Interpreter) evaluateAssignment(expression *BinaryExpression) (bool, error) {
 	relationAttribute := expression.left.(*IdentifierExpression).value
-	assignedValue := expression.right.(*IdentifierExpression).value
 
 	attr := model.Attribute{}
 	complexAttribute, err := attr.ExtractAttribute(relationAttribute, expression.position)
@@ -569,6 +840,11 @@ func (i *Interpreter) evaluateAssignment(expression *BinaryExpression) (bool, er
 		return false, err
 	}
 
+	// The assigned value is evaluated for every held tuple in turn, so that
+	// it may refer to the tuple's own attributes.
+	previous, _ := i.repository.GetRow(complexAttribute.Relation)
+	defer i.restoreRow(complexAttribute.Relation, previous)
+
 	for row := range *relation {
 		if _, exists := (*row)[complexAttribute.Attribute]; !exists {
 			return false, &entity.CustomError{
@@ -578,7 +854,13 @@ func (i *Interpreter) evaluateAssignment(expression *BinaryExpression) (bool, er
 			}
 		}
 
-		(*row)[complexAttribute.Attribute] = []string{assignedValue}
+		i.repository.AddRow(complexAttribute.Relation, row)
+		value, err := i.evaluateOperand(expression.right)
+		if err != nil {
+			return false, err
+		}
+
+		(*row)[complexAttribute.Attribute] = slices.Clone(value)
 	}
 
 	return true, nil
@@ -604,3 +886,85 @@ func (i *Interpreter) evaluateRelease(expression *UnaryExpression) (bool, error)
 	i.repository.ReleaseHeldRelation(relationName)
 	return true, nil
 }
+
+func (i *Interpreter) evaluateDelete(expression *UnaryExpression) (bool, error) {
+	relationName := expression.expression.(*IdentifierExpression).value
+	if _, err := i.repository.GetHeldRelation(relationName); err != nil {
+		err.(*entity.CustomError).Position = expression.position
+		return false, err
+	}
+
+	source, err := i.repository.GetHeldSource(relationName)
+	if err != nil {
+		err.(*entity.CustomError).Position = expression.position
+		return false, err
+	}
+
+	relation, err := i.repository.GetRelation(source)
+	if err != nil {
+		return false, err
+	}
+
+	tuples := i.repository.GetHeldTuples(relationName)
+	for row := range *relation {
+		for _, tuple := range tuples {
+			if row.RowsEqual(tuple) {
+				delete(*relation, row)
+				break
+			}
+		}
+	}
+
+	i.repository.ReleaseHeldRelation(relationName)
+	return true, nil
+}
+
+func (i *Interpreter) evaluatePut(expression *PutExpression) (bool, error) {
+	if len(expression.relations) != 1 || expression.relations[0].GetKind() != model.FREE_RELATION.String() {
+		return false, &entity.CustomError{
+			ErrorType: entity.ResponseTypes["CE"],
+			Message:   "PUT expects exactly one relation",
+			Position:  expression.position,
+		}
+	}
+
+	workspaceName := expression.variable.(*IdentifierExpression).value
+	workspace, err := i.repository.GetHeldRelation(workspaceName)
+	if err != nil {
+		if workspace, err = i.repository.GetGetRelation(workspaceName); err != nil {
+			err.(*entity.CustomError).Position = expression.position
+			return false, err
+		}
+	}
+
+	relationName := expression.relations[0].(*IdentifierExpression).value
+	relation, err := i.repository.GetRelation(relationName)
+	if err != nil {
+		err.(*entity.CustomError).Position = expression.position
+		return false, err
+	}
+
+	if err = relation.EqualArity(workspace, expression.position); err != nil {
+		return false, err
+	}
+
+	// Every tuple is checked before any is inserted, so that a PUT either
+	// inserts the whole workspace or leaves the relation unchanged.
+	for row := range *workspace {
+		for existing := range *relation {
+			if existing.RowsEqual(row) {
+				return false, &entity.CustomError{
+					ErrorType: entity.ResponseTypes["RT"],
+					Message:   fmt.Sprintf("duplicate tuple in relation %s", relationName),
+					Position:  expression.position,
+				}
+			}
+		}
+	}
+
+	for row := range *workspace {
+		(*relation)[row.Copy()] = struct{}{}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,420 @@
+package operation
+
+import (
+	"alpha-executor/entity"
+	"alpha-executor/repository"
+	"bufio"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const employees = `{
+	"EMP": [
+		{"id": ["1"], "name": ["ann"], "dept": ["d1"], "salary": ["100"]},
+		{"id": ["2"], "name": ["bob"], "dept": ["d1"], "salary": ["250"]},
+		{"id": ["3"], "name": ["cid"], "dept": ["d2"], "salary": ["90"]},
+		{"id": ["4"], "name": ["ann"], "dept": ["d3"], "salary": ["300"]}
+	],
+	"DEPT": [
+		{"dept": ["d1"], "title": ["sales"]},
+		{"dept": ["d2"], "title": ["it"]},
+		{"dept": ["d3"], "title": ["ops"]}
+	]
+}`
+
+func newRepository() *repository.AlphaRepository {
+	return repository.NewAlphaRepository(
+		make(entity.RowsMap),
+		make(entity.Relations),
+		make(entity.Relations),
+		make(entity.Relations),
+		make(map[string]string),
+		make(map[string][]*entity.RowMap),
+		make(entity.Workspaces),
+		make(map[string]int),
+	)
+}
+
+func parseRelations(t *testing.T, data string) entity.Relations {
+	t.Helper()
+	var relations entity.Relations
+	if err := json.Unmarshal([]byte(data), &relations); err != nil {
+		t.Fatalf("relations: %v", err)
+	}
+
+	return relations
+}
+
+// run evaluates an ALPHA program as the execute endpoint does and returns
+// its GET workspaces and the relations after it, also when it fails at run
+// time.
+func run(t *testing.T, data string, query string) (entity.Workspaces, entity.Relations, error) {
+	t.Helper()
+	program, errors := GenerateAST(bufio.NewReader(strings.NewReader(query)))
+	if len(errors) > 0 {
+		return nil, nil, entity.CustomErrors(errors)
+	}
+
+	return evaluate(t, data, program)
+}
+
+func evaluate(t *testing.T, data string, program Program) (entity.Workspaces, entity.Relations, error) {
+	t.Helper()
+	relations := parseRelations(t, data)
+	schemas, err := entity.NewSchemas(relations, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if errors := NewBinder(schemas).Bind(&program); len(errors) > 0 {
+		return nil, nil, entity.CustomErrors(errors)
+	}
+
+	if errors := NewTypeChecker(schemas).Check(&program); len(errors) > 0 {
+		return nil, nil, entity.CustomErrors(errors)
+	}
+
+	alphaRepository := newRepository()
+	alphaRepository.AddRelations(relations)
+	err = NewInterpreter(alphaRepository).Evaluate(&program)
+	return alphaRepository.GetGetRelations(), relations, err
+}
+
+// expectRows checks that a relation has exactly the rows of expected, a
+// JSON array, in any order.
+func expectRows(t *testing.T, relation *entity.Relation, expected string) {
+	t.Helper()
+	var rows entity.Relation
+	if err := json.Unmarshal([]byte(expected), &rows); err != nil {
+		t.Fatalf("expected rows: %v", err)
+	}
+
+	if relation == nil || !rows.RelationEqual(relation) {
+		actual, _ := json.Marshal(relation)
+		t.Errorf("got %s, want %s", actual, expected)
+	}
+}
+
+// expectOrder checks that a workspace has the rows of expected in order.
+func expectOrder(t *testing.T, workspace *entity.Workspace, expected string) {
+	t.Helper()
+	var rows entity.Workspace
+	if err := json.Unmarshal([]byte(expected), &rows); err != nil {
+		t.Fatalf("expected rows: %v", err)
+	}
+
+	if workspace == nil || !workspace.IsOrdered() || !rows.OrderEqual(workspace) {
+		actual, _ := json.Marshal(workspace)
+		t.Errorf("got %s, want %s in this order", actual, expected)
+	}
+}
+
+func expectWorkspace(t *testing.T, data, query, name, expected string) {
+	t.Helper()
+	workspaces, _, err := run(t, data, query)
+	if err != nil {
+		t.Fatalf("%s: %v", query, err)
+	}
+
+	if workspaces[name] == nil {
+		t.Fatalf("%s: no workspace %s", query, name)
+	}
+
+	expectRows(t, workspaces[name].Relation, expected)
+}
+
+func expectError(t *testing.T, err error, message string) {
+	t.Helper()
+	if err == nil || !strings.Contains(err.Error(), message) {
+		t.Errorf("got error %v, want one containing %q", err, message)
+	}
+}
+
+func TestDeleteRemovesOnlyHeldTuples(t *testing.T) {
+	_, relations, err := run(t, employees, `HOLD H (EMP.name): EMP.id = 1; DELETE H`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectRows(t, relations["EMP"], `[
+		{"id": ["2"], "name": ["bob"], "dept": ["d1"], "salary": ["250"]},
+		{"id": ["3"], "name": ["cid"], "dept": ["d2"], "salary": ["90"]},
+		{"id": ["4"], "name": ["ann"], "dept": ["d3"], "salary": ["300"]}
+	]`)
+}
+
+func TestDeleteHeldRelation(t *testing.T) {
+	_, relations, err := run(t, employees, `HOLD H (EMP): EMP.salary > 95; DELETE H`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectRows(t, relations["EMP"], `[{"id": ["3"], "name": ["cid"], "dept": ["d2"], "salary": ["90"]}]`)
+}
+
+func TestDeleteNeedsASingleSourceRelation(t *testing.T) {
+	_, _, err := run(t, employees, `HOLD H (EMP.id, DEPT.title): EMP.dept = DEPT.dept; DELETE H`)
+	expectError(t, err, "relation H isn't held from a single relation")
+
+	_, _, err = run(t, employees, `GET W (EMP): EMP.id = 1; DELETE W`)
+	expectError(t, err, "workspace W is not held")
+}
+
+func TestPutInsertsWorkspace(t *testing.T) {
+	data := `{"EMP": [{"id": ["1"], "name": ["ann"]}], "NEW": [{"id": ["2"], "name": ["bob"]}]}`
+	_, relations, err := run(t, data, `GET W (NEW); PUT W (EMP)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectRows(t, relations["EMP"], `[{"id": ["1"], "name": ["ann"]}, {"id": ["2"], "name": ["bob"]}]`)
+}
+
+func TestPutErrors(t *testing.T) {
+	data := `{"EMP": [{"id": ["1"], "name": ["ann"]}], "NEW": [{"id": ["1"], "name": ["ann"]}], "ID": [{"id": ["2"]}]}`
+	tests := []struct {
+		query   string
+		message string
+	}{
+		{`GET W (NEW); PUT W (EMP)`, "duplicate tuple in relation EMP"},
+		{`GET W (ID); PUT W (EMP)`, "Incorrect arity at 1:13"},
+		{`GET W (NEW); PUT W (EMP, ID)`, "PUT expects exactly one relation"},
+	}
+
+	for _, test := range tests {
+		_, relations, err := run(t, data, test.query)
+		expectError(t, err, test.message)
+		expectRows(t, relations["EMP"], `[{"id": ["1"], "name": ["ann"]}]`)
+	}
+}
+
+func TestPutIsAtomic(t *testing.T) {
+	data := `{
+		"EMP": [{"id": ["1"], "name": ["ann"]}],
+		"NEW": [{"id": ["1"], "name": ["ann"]}, {"id": ["2"], "name": ["bob"]}, {"id": ["3"], "name": ["cid"]}]
+	}`
+	_, relations, err := run(t, data, `GET W (NEW); PUT W (EMP)`)
+	expectError(t, err, "duplicate tuple in relation EMP")
+	expectRows(t, relations["EMP"], `[{"id": ["1"], "name": ["ann"]}]`)
+}
+
+func TestHeldTuplesAreCopies(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{`HOLD W (EMP): EMP.id = 1; W.id = 9; RELEASE W`, `[{"id": ["1"]}, {"id": ["2"]}, {"id": ["3"]}, {"id": ["4"]}]`},
+		{`HOLD W (EMP): EMP.id = 1; W.id = 9; PUT W (EMP)`, `[{"id": ["1"]}, {"id": ["2"]}, {"id": ["3"]}, {"id": ["4"]}, {"id": ["9"]}]`},
+		{`HOLD W (EMP): EMP.id = 1; W.id = 9; DELETE W`, `[{"id": ["2"]}, {"id": ["3"]}, {"id": ["4"]}]`},
+	}
+
+	for _, test := range tests {
+		expectWorkspace(t, employees, test.query+`; GET V (EMP.id)`, "V", test.expected)
+	}
+}
+
+// sortIDs sorts the relation EMP of data by the sort clause of a GET and
+// returns the ids of the sorted tuples.
+func sortIDs(t *testing.T, data, query string) ([]string, error) {
+	t.Helper()
+	program, errors := GenerateAST(bufio.NewReader(strings.NewReader(query)))
+	if len(errors) > 0 {
+		t.Fatalf("%s: %v", query, entity.CustomErrors(errors))
+	}
+
+	relations := parseRelations(t, data)
+	sorted, err := NewInterpreter(newRepository()).evaluateSort(program.body[0].(*GetHoldExpression).sort, relations["EMP"])
+	ids := make([]string, len(sorted))
+	for i, row := range sorted {
+		ids[i] = (*row)["id"][0]
+	}
+
+	return ids, err
+}
+
+func TestSortByKeysBreaksTies(t *testing.T) {
+	data := `{"EMP": [
+		{"id": ["1"], "name": ["cid"], "dept": ["d2"], "salary": ["100"]},
+		{"id": ["2"], "name": ["ann"], "dept": ["d1"], "salary": ["90"]},
+		{"id": ["3"], "name": ["bob"], "dept": ["d2"], "salary": ["1000"]},
+		{"id": ["4"], "name": ["ann"], "dept": ["d1"], "salary": ["100"]},
+		{"id": ["5"], "name": ["bob"], "dept": ["d1"], "salary": ["100"]}
+	]}`
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{`GET W (EMP) DOWN EMP.salary, UP EMP.name`, "3 4 5 1 2"},
+		{`GET W (EMP) UP EMP.salary, DOWN EMP.name`, "2 1 5 4 3"},
+		{`GET W (EMP) UP EMP.dept, DOWN EMP.salary, UP EMP.name`, "4 5 2 3 1"},
+		{`GET W (EMP) UP EMP.id`, "1 2 3 4 5"},
+	}
+
+	for _, test := range tests {
+		ids, err := sortIDs(t, data, test.query)
+		if err != nil {
+			t.Fatalf("%s: %v", test.query, err)
+		}
+
+		if actual := strings.Join(ids, " "); actual != test.expected {
+			t.Errorf("%s: got %s, want %s", test.query, actual, test.expected)
+		}
+	}
+}
+
+func TestSortByUnknownAttribute(t *testing.T) {
+	_, err := sortIDs(t, employees, `GET W (EMP) UP EMP.name, DOWN EMP.grade`)
+	expectError(t, err, "Attribute grade doesn't exist")
+}
+
+func TestSortedGetIsOrdered(t *testing.T) {
+	workspaces, _, err := run(t, employees, `GET W (EMP.name, EMP.salary) DOWN EMP.name, UP EMP.salary; GET V (EMP.name)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectOrder(t, workspaces["W"], `[
+		{"name": ["cid"], "salary": ["90"]},
+		{"name": ["bob"], "salary": ["250"]},
+		{"name": ["ann"], "salary": ["100"]},
+		{"name": ["ann"], "salary": ["300"]}
+	]`)
+	if workspaces["V"].IsOrdered() {
+		t.Errorf("got an ordered workspace V without a sort clause")
+	}
+}
+func TestRowLimitTakesFirstTuplesInSortOrder(t *testing.T) {
+	workspaces, _, err := run(t, employees, `GET W (2) (EMP.id, EMP.salary) DOWN EMP.salary`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectOrder(t, workspaces["W"], `[{"id": ["4"], "salary": ["300"]}, {"id": ["2"], "salary": ["250"]}]`)
+}
+
+func TestRepeatedLimitedGetAdvancesCursor(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{`GET W (2) (EMP.id) UP EMP.id`, `[{"id": ["1"]}, {"id": ["2"]}]`},
+		{`GET W (2) (EMP.id) UP EMP.id; GET W (2) (EMP.id) UP EMP.id`, `[{"id": ["3"]}, {"id": ["4"]}]`},
+		{`GET W (3) (EMP.id) UP EMP.id; GET W (3) (EMP.id) UP EMP.id`, `[{"id": ["4"]}]`},
+		{`GET W (2) (EMP.id) UP EMP.id; GET W (2) (EMP.id) UP EMP.id; GET W (2) (EMP.id) UP EMP.id`, `[]`},
+	}
+
+	for _, test := range tests {
+		workspaces, _, err := run(t, employees, test.query)
+		if err != nil {
+			t.Fatalf("%s: %v", test.query, err)
+		}
+
+		expectOrder(t, workspaces["W"], test.expected)
+	}
+}
+
+func TestRowLimitWithoutSortIsReproducible(t *testing.T) {
+	expectWorkspace(t, employees, `GET W (1) (EMP.id); GET W (1) (EMP.id)`, "W", `[{"id": ["2"]}]`)
+}
+
+func TestStringConstants(t *testing.T) {
+	data := `{"EMP": [{"id": ["1"], "name": ["O'Brien, J."]}, {"id": ["2"], "name": ["Ivanov \"Ivan\""]}]}`
+	expectWorkspace(t, data, `GET W (EMP.id): EMP.name = "O'Brien, J."`, "W", `[{"id": ["1"]}]`)
+	expectWorkspace(t, data, `GET W (EMP.id): EMP.name = "Ivanov \"Ivan\""`, "W", `[{"id": ["2"]}]`)
+}
+
+func TestTemporalValues(t *testing.T) {
+	data := `{"EMP": [
+		{"id": ["1"], "hired": ["01.12.2000"], "start": ["9:30"]},
+		{"id": ["2"], "hired": ["05.03.2001"], "start": ["10:15"]},
+		{"id": ["3"], "hired": ["2000-06-01 12:00:00"], "start": ["08:00:00"]}
+	]}`
+	expectWorkspace(t, data, `GET W (EMP.id): EMP.hired > "2000-12-01"`, "W", `[{"id": ["2"]}]`)
+	expectWorkspace(t, data, `GET W (EMP.id): EMP.hired >= "01.12.2000"`, "W", `[{"id": ["1"]}, {"id": ["2"]}]`)
+	expectWorkspace(t, data, `GET W (EMP.id): EMP.start < "10:00"`, "W", `[{"id": ["1"]}, {"id": ["3"]}]`)
+
+	workspaces, _, err := run(t, data, `GET W (EMP.id) UP EMP.hired; GET V (EMP.id) DOWN EMP.start`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectOrder(t, workspaces["W"], `[{"id": ["3"]}, {"id": ["1"]}, {"id": ["2"]}]`)
+	expectOrder(t, workspaces["V"], `[{"id": ["2"]}, {"id": ["1"]}, {"id": ["3"]}]`)
+}
+
+func TestWorkspacesChainAsRelations(t *testing.T) {
+	query := `GET W1 (EMP.id, EMP.dept): EMP.salary > 95;
+		RANGE W1 Z;
+		GET W2 (DEPT.title): ∃Z (Z.dept = DEPT.dept);
+		GET W3 (W1.id): W1.dept = "d1"`
+	workspaces, _, err := run(t, employees, query)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(workspaces) != 3 {
+		t.Errorf("got workspaces %v, want W1, W2 and W3", workspaces)
+	}
+
+	expectRows(t, workspaces["W2"].Relation, `[{"title": ["sales"]}, {"title": ["ops"]}]`)
+	expectRows(t, workspaces["W3"].Relation, `[{"id": ["1"]}, {"id": ["2"]}]`)
+}
+
+func TestWorkspaceChainingErrors(t *testing.T) {
+	_, _, err := run(t, employees, `GET EMP (DEPT.dept)`)
+	expectError(t, err, "workspace EMP conflicts with relation EMP")
+
+	_, _, err = run(t, employees, `GET W (EMP.salary); GET V (W.salary): W.salary > "ann"`)
+	expectError(t, err, "cannot compare number with string")
+}
+
+func TestComparisonWithConstantOnTheLeft(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{`GET W (EMP.id): 95 < EMP.salary`, `[{"id": ["1"]}, {"id": ["2"]}, {"id": ["4"]}]`},
+		{`GET W (EMP.id): EMP.salary > 95`, `[{"id": ["1"]}, {"id": ["2"]}, {"id": ["4"]}]`},
+		{`GET W (EMP.id): 100 >= EMP.salary`, `[{"id": ["1"]}, {"id": ["3"]}]`},
+		{`GET W (EMP.id): "d1" = EMP.dept`, `[{"id": ["1"]}, {"id": ["2"]}]`},
+		{`GET W (EMP.id): 200 < EMP.salary - EMP.id`, `[{"id": ["2"]}, {"id": ["4"]}]`},
+	}
+
+	for _, test := range tests {
+		expectWorkspace(t, employees, test.query, "W", test.expected)
+	}
+}
+
+func TestAssignment(t *testing.T) {
+	tests := []struct {
+		assignment string
+		expected   string
+	}{
+		{`W.salary = 5`, `[{"id": ["1"], "salary": ["5"]}]`},
+		{`W.salary = NULL`, `[{"id": ["1"], "salary": null}]`},
+		{`W.salary = W.salary + 1`, `[{"id": ["1"], "salary": ["101"]}]`},
+		{`W.salary = -W.id * 2`, `[{"id": ["1"], "salary": ["-2"]}]`},
+	}
+
+	for _, test := range tests {
+		query := `HOLD W (EMP.id, EMP.salary): EMP.id = 1; ` + test.assignment + `; UPDATE W; GET V (W)`
+		expectWorkspace(t, employees, query, "V", test.expected)
+	}
+}
+
+func TestAssignmentErrors(t *testing.T) {
+	tests := []struct {
+		assignment string
+		message    string
+	}{
+		{`W.salary = W.name`, "incorrect attribute name"},
+		{`W.salary = "x" + 1`, "arithmetic on non-numeric value x"},
+		{`W.grade = 1`, "incorrect attribute grade of W"},
+	}
+
+	for _, test := range tests {
+		_, _, err := run(t, employees, `HOLD W (EMP.id, EMP.salary): EMP.id = 1; `+test.assignment)
+		expectError(t, err, test.message)
+	}
+}
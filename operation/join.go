@@ -3,15 +3,32 @@ package operation
 import (
 	"alpha-executor/entity"
 	"alpha-executor/model"
+	"cmp"
+	"encoding/json"
+	"slices"
 )
 
+// Join is the natural join on the given attributes: two rows match when
+// their value lists of every one of them are equal. The smaller relation is
+// hashed on these lists and the larger one probes the table; when the
+// smaller one has more than hashJoinLimit rows, both are sorted on the lists
+// and merged instead. The product of the relations is never built.
 type Join struct {
 }
 
-func (*Join) Execute(relation1, relation2 entity.Pair[string, *entity.Relation], attributes []string) (*entity.Relation, error) {
+const hashJoinLimit = 1 << 16
+
+// keyedRow is a row with the encoding of its values of the join attributes.
+type keyedRow struct {
+	key string
+	row *entity.RowMap
+}
+
+func (j *Join) Execute(relation1, relation2 entity.Pair[string, *entity.Relation], attributes []string) (*entity.Relation, error) {
 	joined := make(entity.Relation)
-	times := Product{}
-	product := times.Execute(relation1.Right, relation2.Right)
+	if len(*relation1.Right) == 0 || len(*relation2.Right) == 0 {
+		return &joined, nil
+	}
 
 	var relations entity.Relations = map[string]*entity.Relation{
 		relation1.Left: relation1.Right,
@@ -19,36 +36,146 @@ func (*Join) Execute(relation1, relation2 entity.Pair[string, *entity.Relation],
 	}
 
 	attr := model.Attribute{}
-	for row := range *product {
-		joinedAttributes := 0
-		for _, attribute := range attributes {
-			slicedAttribute, err := attr.ReturnExistentAttribute(relations, attribute)
-			if err != nil {
-				return nil, &entity.CustomError{
-					ErrorType: entity.ResponseTypes["CE"],
-					Message:   err.Error(),
-				}
+	keys := make([]string, 0, len(attributes))
+	for _, attribute := range attributes {
+		if j.hasAttribute(relation1.Right, attribute) && j.hasAttribute(relation2.Right, attribute) {
+			if !slices.Contains(keys, attribute) {
+				keys = append(keys, attribute)
 			}
 
-			values, exist := (*row)[slicedAttribute]
-			if !exist {
-				continue
+			continue
+		}
+
+		slicedAttribute, err := attr.ReturnExistentAttribute(relations, attribute)
+		if err != nil {
+			return nil, &entity.CustomError{
+				ErrorType: entity.ResponseTypes["CE"],
+				Message:   err.Error(),
+			}
+		}
+
+		if !slices.Contains(keys, slicedAttribute) {
+			keys = append(keys, slicedAttribute)
+		}
+	}
+
+	left, right := j.keyRows(relation1.Right, keys), j.keyRows(relation2.Right, keys)
+	if min(len(left), len(right)) > hashJoinLimit {
+		j.sortMerge(left, right, keys, &joined)
+	} else {
+		j.hash(left, right, keys, &joined)
+	}
+
+	return &joined, nil
+}
+
+func (j *Join) hash(left, right []keyedRow, keys []string, joined *entity.Relation) {
+	swapped := len(right) < len(left)
+	build, probe := left, right
+	if swapped {
+		build, probe = right, left
+	}
+
+	table := make(map[string][]*entity.RowMap, len(build))
+	for _, keyed := range build {
+		table[keyed.key] = append(table[keyed.key], keyed.row)
+	}
+
+	for _, keyed := range probe {
+		for _, match := range table[keyed.key] {
+			if swapped {
+				(*joined)[j.mergeRows(keyed.row, match, keys)] = struct{}{}
+			} else {
+				(*joined)[j.mergeRows(match, keyed.row, keys)] = struct{}{}
 			}
+		}
+	}
+}
 
-			if len(values)%2 == 0 {
-				part1 := values[:len(values)/2]
-				part2 := values[len(values)/2:]
+func (j *Join) sortMerge(left, right []keyedRow, keys []string, joined *entity.Relation) {
+	byKey := func(a, b keyedRow) int { return cmp.Compare(a.key, b.key) }
+	slices.SortFunc(left, byKey)
+	slices.SortFunc(right, byKey)
 
-				if entity.OrderedSlicesEqual(part1, part2) {
-					joinedAttributes++
-					(*row)[slicedAttribute] = part1
+	for l, r := 0, 0; l < len(left) && r < len(right); {
+		switch comparison := cmp.Compare(left[l].key, right[r].key); {
+		case comparison < 0:
+			l++
+		case comparison > 0:
+			r++
+		default:
+			lEnd, rEnd := l, r
+			for lEnd < len(left) && left[lEnd].key == left[l].key {
+				lEnd++
+			}
+
+			for rEnd < len(right) && right[rEnd].key == right[r].key {
+				rEnd++
+			}
+
+			for _, row1 := range left[l:lEnd] {
+				for _, row2 := range right[r:rEnd] {
+					(*joined)[j.mergeRows(row1.row, row2.row, keys)] = struct{}{}
 				}
 			}
+
+			l, r = lEnd, rEnd
 		}
+	}
+}
 
-		if joinedAttributes == len(attributes) {
-			joined[row] = struct{}{}
+// hasAttribute reports whether every row of relation has attribute. An empty
+// relation has no known attributes, so it has none; Execute never asks, as it
+// returns early when an operand is empty.
+func (*Join) hasAttribute(relation *entity.Relation, attribute string) bool {
+	for row := range *relation {
+		if _, exists := (*row)[attribute]; !exists {
+			return false
 		}
 	}
-	return &joined, nil
+
+	return len(*relation) > 0
+}
+
+// keyRows encodes the value lists of the join attributes of every row; rows
+// lacking one of the attributes match no row and are left out.
+func (*Join) keyRows(relation *entity.Relation, keys []string) []keyedRow {
+	keyed := make([]keyedRow, 0, len(*relation))
+	for row := range *relation {
+		values := make([][]string, len(keys))
+		complete := true
+		for i, key := range keys {
+			if values[i], complete = (*row)[key]; !complete {
+				break
+			}
+
+			if values[i] == nil {
+				values[i] = []string{}
+			}
+		}
+
+		if complete {
+			encoded, _ := json.Marshal(values)
+			keyed = append(keyed, keyedRow{string(encoded), row})
+		}
+	}
+
+	return keyed
+}
+
+// mergeRows combines two matching rows; the join attributes, equal in both,
+// are taken once.
+func (*Join) mergeRows(row1, row2 *entity.RowMap, keys []string) *entity.RowMap {
+	row := make(entity.RowMap, len(*row1)+len(*row2))
+	for key, values := range *row1 {
+		row[key] = append(row[key], values...)
+	}
+
+	for key, values := range *row2 {
+		if !slices.Contains(keys, key) {
+			row[key] = append(row[key], values...)
+		}
+	}
+
+	return &row
 }
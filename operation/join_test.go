@@ -0,0 +1,149 @@
+package operation
+
+import (
+	"alpha-executor/entity"
+	"encoding/json"
+	"testing"
+)
+
+func pair(t *testing.T, name, rows string) entity.Pair[string, *entity.Relation] {
+	t.Helper()
+	var relation entity.Relation
+	if err := json.Unmarshal([]byte(rows), &relation); err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+
+	return entity.Pair[string, *entity.Relation]{Left: name, Right: &relation}
+}
+
+func TestJoinOnCommonAttributes(t *testing.T) {
+	tests := []struct {
+		name     string
+		left     string
+		right    string
+		keys     []string
+		expected string
+	}{
+		{
+			"single key",
+			`[{"a": ["1"], "b": ["x"]}, {"a": ["2"], "b": ["y"]}, {"a": ["3"], "b": ["z"]}]`,
+			`[{"a": ["1"], "c": ["p"]}, {"a": ["1"], "c": ["q"]}, {"a": ["3"], "c": ["r"]}]`,
+			[]string{"a"},
+			`[{"a": ["1"], "b": ["x"], "c": ["p"]}, {"a": ["1"], "b": ["x"], "c": ["q"]}, {"a": ["3"], "b": ["z"], "c": ["r"]}]`,
+		},
+		{
+			"two keys",
+			`[{"a": ["1"], "b": ["x"], "c": ["p"]}, {"a": ["1"], "b": ["y"], "c": ["q"]}]`,
+			`[{"a": ["1"], "b": ["y"], "d": ["r"]}]`,
+			[]string{"a", "b"},
+			`[{"a": ["1"], "b": ["y"], "c": ["q"], "d": ["r"]}]`,
+		},
+		{
+			"multi-valued attributes match on the whole list",
+			`[{"a": ["1", "2"], "b": ["x"]}, {"a": ["1"], "b": ["y"]}, {"a": ["2", "1"], "b": ["z"]}]`,
+			`[{"a": ["1", "2"], "c": ["p"]}]`,
+			[]string{"a"},
+			`[{"a": ["1", "2"], "b": ["x"], "c": ["p"]}]`,
+		},
+		{
+			"no common attributes is a product",
+			`[{"a": ["1"]}, {"a": ["2"]}]`,
+			`[{"c": ["p"]}, {"c": ["q"]}]`,
+			[]string{},
+			`[{"a": ["1"], "c": ["p"]}, {"a": ["1"], "c": ["q"]}, {"a": ["2"], "c": ["p"]}, {"a": ["2"], "c": ["q"]}]`,
+		},
+		{
+			"empty operand",
+			`[]`,
+			`[{"a": ["1"]}]`,
+			[]string{"a"},
+			`[]`,
+		},
+	}
+
+	join := Join{}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			joined, err := join.Execute(pair(t, "L", test.left), pair(t, "R", test.right), test.keys)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expectRows(t, joined, test.expected)
+		})
+	}
+}
+
+// TestHashAndSortMergeAgree joins the same rows with both strategies, since
+// sort-merge only runs on relations too large for a unit test.
+func TestHashAndSortMergeAgree(t *testing.T) {
+	left := pair(t, "L", `[
+		{"a": ["1"], "b": ["x"]}, {"a": ["1"], "b": ["y"]}, {"a": ["2"], "b": ["z"]}, {"a": ["4"], "b": ["w"]}
+	]`)
+	right := pair(t, "R", `[
+		{"a": ["1"], "c": ["p"]}, {"a": ["2"], "c": ["q"]}, {"a": ["2"], "c": ["r"]}, {"a": ["5"], "c": ["t"]}
+	]`)
+
+	join := Join{}
+	keys := []string{"a"}
+	hashed, merged := make(entity.Relation), make(entity.Relation)
+	join.hash(join.keyRows(left.Right, keys), join.keyRows(right.Right, keys), keys, &hashed)
+	join.sortMerge(join.keyRows(left.Right, keys), join.keyRows(right.Right, keys), keys, &merged)
+
+	expected := `[
+		{"a": ["1"], "b": ["x"], "c": ["p"]}, {"a": ["1"], "b": ["y"], "c": ["p"]},
+		{"a": ["2"], "b": ["z"], "c": ["q"]}, {"a": ["2"], "b": ["z"], "c": ["r"]}
+	]`
+	expectRows(t, &hashed, expected)
+	expectRows(t, &merged, expected)
+}
+
+func TestHasAttribute(t *testing.T) {
+	tests := []struct {
+		name     string
+		rows     string
+		expected bool
+	}{
+		{"every row", `[{"a": ["1"], "b": ["x"]}, {"a": []}]`, true},
+		{"some rows", `[{"a": ["1"]}, {"b": ["x"]}]`, false},
+		{"no rows", `[]`, false},
+	}
+
+	join := Join{}
+	for _, test := range tests {
+		if actual := join.hasAttribute(pair(t, test.name, test.rows).Right, "a"); actual != test.expected {
+			t.Errorf("%s: got %t, want %t", test.name, actual, test.expected)
+		}
+	}
+}
+
+// TestGetJoinsOnAttributesOfEveryTuple runs each query repeatedly, as the
+// rows of a relation come in a different order every time.
+func TestGetJoinsOnAttributesOfEveryTuple(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{
+			"ragged tuples",
+			`{"EMP": [{"name": ["ann"], "dept": ["d1"]}, {"name": ["bob"], "dept": ["d1"]}, {"name": ["cid"]}], "DEPT": [{"dept": ["d1"], "title": ["sales"]}, {"dept": ["d2"], "title": ["it"]}]}`,
+			`[
+				{"name": ["ann"], "title": ["sales"]}, {"name": ["ann"], "title": ["it"]},
+				{"name": ["bob"], "title": ["sales"]}, {"name": ["bob"], "title": ["it"]},
+				{"name": ["cid"], "title": ["sales"]}, {"name": ["cid"], "title": ["it"]}
+			]`,
+		},
+		{
+			"NULL values",
+			`{"EMP": [{"name": ["ann"], "dept": null}, {"name": ["bob"], "dept": ["d1"]}], "DEPT": [{"dept": ["d1"], "title": ["sales"]}, {"dept": ["d2"], "title": ["it"]}]}`,
+			`[{"name": ["bob"], "title": ["sales"]}]`,
+		},
+	}
+
+	for _, test := range tests {
+		for range 10 {
+			expectWorkspace(t, test.data, `GET W (EMP.name, DEPT.title)`, "W", test.expected)
+		}
+	}
+}
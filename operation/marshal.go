@@ -0,0 +1,108 @@
+package operation
+
+import (
+	"alpha-executor/entity"
+	"encoding/json"
+)
+
+// The AST is serialised for tools such as editors: every node carries its
+// kind, and every node but the program its source position.
+
+func (p *Program) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind string       `json:"kind"`
+		Body []Expression `json:"body"`
+	}{p.kind, p.body})
+}
+
+func (b *BinaryExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind     string          `json:"kind"`
+		Left     Expression      `json:"left"`
+		Right    Expression      `json:"right"`
+		Position entity.Position `json:"position"`
+	}{b.kind, b.left, b.right, b.position})
+}
+
+func (b *UnaryExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind       string          `json:"kind"`
+		Expression Expression      `json:"expression"`
+		Position   entity.Position `json:"position"`
+	}{b.kind, b.expression, b.position})
+}
+
+func (i *IdentifierExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind     string          `json:"kind"`
+		Value    string          `json:"value"`
+		Position entity.Position `json:"position"`
+	}{i.kind, i.value, i.position})
+}
+
+func (f *FunctionExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind          string          `json:"kind"`
+		Argument      Expression      `json:"argument"`
+		Qualification Expression      `json:"qualification"`
+		Position      entity.Position `json:"position"`
+	}{f.kind, f.argument, f.qualification, f.position})
+}
+
+func (i *InExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind     string          `json:"kind"`
+		Operand  Expression      `json:"operand"`
+		Values   []Expression    `json:"values"`
+		Position entity.Position `json:"position"`
+	}{i.kind, i.operand, i.values, i.position})
+}
+
+func (b *BetweenExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind     string          `json:"kind"`
+		Operand  Expression      `json:"operand"`
+		Lower    Expression      `json:"lower"`
+		Upper    Expression      `json:"upper"`
+		Position entity.Position `json:"position"`
+	}{b.kind, b.operand, b.lower, b.upper, b.position})
+}
+
+func (p *PatternExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind     string          `json:"kind"`
+		Operand  Expression      `json:"operand"`
+		Pattern  Expression      `json:"pattern"`
+		Position entity.Position `json:"position"`
+	}{p.kind, p.operand, p.pattern, p.position})
+}
+
+func (g *GetHoldExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind          string          `json:"kind"`
+		Variable      Expression      `json:"variable"`
+		Rows          Expression      `json:"rows"`
+		Relations     []Expression    `json:"relations"`
+		Qualification Expression      `json:"qualification"`
+		Sort          []Expression    `json:"sort"`
+		Position      entity.Position `json:"position"`
+	}{g.kind, g.variable, g.rows, g.relations, g.expression, g.sort, g.position})
+}
+
+func (r *RangeExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind     string          `json:"kind"`
+		Relation Expression      `json:"relation"`
+		Variable Expression      `json:"variable"`
+		Position entity.Position `json:"position"`
+	}{r.kind, r.relation, r.variable, r.position})
+}
+
+func (p *PutExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind      string          `json:"kind"`
+		Variable  Expression      `json:"variable"`
+		Relations []Expression    `json:"relations"`
+		Position  entity.Position `json:"position"`
+	}{p.kind, p.variable, p.relations, p.position})
+}
@@ -0,0 +1,112 @@
+package operation
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func expectJSON(t *testing.T, value any, expected string) {
+	t.Helper()
+	actual, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var compact bytes.Buffer
+	if err = json.Compact(&compact, []byte(expected)); err != nil {
+		t.Fatalf("expected JSON: %v", err)
+	}
+
+	if string(actual) != compact.String() {
+		t.Errorf("got %s, want %s", actual, compact.String())
+	}
+}
+
+func TestProgramJSON(t *testing.T) {
+	source := "RANGE EMP E;\nGET W (2) (E.id): ¬(E.id = 1) DOWN E.id"
+	program, errors := GenerateAST(bufio.NewReader(strings.NewReader(source)))
+	if len(errors) > 0 {
+		t.Fatal(errors)
+	}
+
+	expectJSON(t, &program, `{"kind": "PROGRAM", "body": [
+		{
+			"kind": "RANGE",
+			"relation": {"kind": "FREE_RELATION", "value": "EMP", "position": {"line": 1, "column": 7}},
+			"variable": {"kind": "FREE_RELATION", "value": "E", "position": {"line": 1, "column": 11}},
+			"position": {"line": 1, "column": 1}
+		},
+		{
+			"kind": "GET",
+			"variable": {"kind": "FREE_RELATION", "value": "W", "position": {"line": 2, "column": 5}},
+			"rows": {"kind": "INTEGER", "value": "2", "position": {"line": 2, "column": 8}},
+			"relations": [{"kind": "ATTRIBUTE", "value": "E.id", "position": {"line": 2, "column": 12}}],
+			"qualification": {
+				"kind": "¬",
+				"expression": {
+					"kind": "=",
+					"left": {"kind": "ATTRIBUTE", "value": "E.id", "position": {"line": 2, "column": 21}},
+					"right": {"kind": "INTEGER", "value": "1", "position": {"line": 2, "column": 28}},
+					"position": {"line": 2, "column": 26}
+				},
+				"position": {"line": 2, "column": 19}
+			},
+			"sort": [{
+				"kind": "DOWN",
+				"expression": {"kind": "ATTRIBUTE", "value": "E.id", "position": {"line": 2, "column": 36}},
+				"position": {"line": 2, "column": 31}
+			}],
+			"position": {"line": 2, "column": 1}
+		}
+	]}`)
+}
+
+func TestProgramJSONOfFunctionsAndPredicates(t *testing.T) {
+	source := `HOLD H (EMP); DELETE H; GET W (COUNT(EMP WHERE EMP.id IN (1, 2)))`
+	program, errors := GenerateAST(bufio.NewReader(strings.NewReader(source)))
+	if len(errors) > 0 {
+		t.Fatal(errors)
+	}
+
+	expectJSON(t, &program, `{"kind": "PROGRAM", "body": [
+		{
+			"kind": "HOLD",
+			"variable": {"kind": "FREE_RELATION", "value": "H", "position": {"line": 1, "column": 6}},
+			"rows": null,
+			"relations": [{"kind": "FREE_RELATION", "value": "EMP", "position": {"line": 1, "column": 9}}],
+			"qualification": null,
+			"sort": [],
+			"position": {"line": 1, "column": 1}
+		},
+		{
+			"kind": "DELETE",
+			"expression": {"kind": "FREE_RELATION", "value": "H", "position": {"line": 1, "column": 22}},
+			"position": {"line": 1, "column": 15}
+		},
+		{
+			"kind": "GET",
+			"variable": {"kind": "FREE_RELATION", "value": "W", "position": {"line": 1, "column": 29}},
+			"rows": null,
+			"relations": [{
+				"kind": "COUNT",
+				"argument": {"kind": "FREE_RELATION", "value": "EMP", "position": {"line": 1, "column": 38}},
+				"qualification": {
+					"kind": "IN",
+					"operand": {"kind": "ATTRIBUTE", "value": "EMP.id", "position": {"line": 1, "column": 48}},
+					"values": [
+						{"kind": "INTEGER", "value": "1", "position": {"line": 1, "column": 59}},
+						{"kind": "INTEGER", "value": "2", "position": {"line": 1, "column": 62}}
+					],
+					"position": {"line": 1, "column": 55}
+				},
+				"position": {"line": 1, "column": 32}
+			}],
+			"qualification": null,
+			"sort": [],
+			"position": {"line": 1, "column": 25}
+		}
+	]}`)
+}
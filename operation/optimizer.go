@@ -0,0 +1,256 @@
+package operation
+
+import (
+	"alpha-executor/entity"
+	"alpha-executor/model"
+	"slices"
+)
+
+// Optimizer plans how the interpreter searches the combinations of rows of
+// the free variables of a GET or HOLD. Evaluating the whole qualification for
+// every combination costs the product of the sizes of their relations, so
+// the qualification is split into its conjuncts instead:
+//
+//   - conjuncts without free variables are evaluated once;
+//   - conjuncts of a single variable filter the relation of that variable
+//     before any combination is formed;
+//   - the variables connected by the remaining conjuncts form groups that are
+//     searched independently, each starting from its smallest relation and
+//     evaluating every conjunct as soon as all of its variables are bound.
+//
+// A conjunction is TRUE if and only if all of its conjuncts are TRUE, so the
+// plan selects exactly the rows the whole qualification selects.
+type Optimizer struct {
+}
+
+// QueryPlan is the qualification of a query split by the variables its
+// conjuncts refer to.
+type QueryPlan struct {
+	variables []string
+	closed    []Expression
+	filters   map[string][]Expression
+	joins     []joinConjunct
+	groups    []*JoinGroup
+}
+
+type joinConjunct struct {
+	expression Expression
+	variables  []string
+}
+
+// JoinGroup is a set of variables connected by conjuncts in the order they
+// are bound; checks[k] are the conjuncts all of whose variables are bound
+// once order[k] is.
+type JoinGroup struct {
+	order  []string
+	checks [][]Expression
+}
+
+func NewOptimizer() *Optimizer {
+	return &Optimizer{}
+}
+
+// Plan splits a qualification over the given free variables into conjuncts.
+func (o *Optimizer) Plan(variables []string, qualification Expression) *QueryPlan {
+	plan := &QueryPlan{
+		variables: variables,
+		closed:    make([]Expression, 0),
+		filters:   make(map[string][]Expression),
+		joins:     make([]joinConjunct, 0),
+	}
+
+	for _, conjunct := range conjuncts(qualification) {
+		free := make([]string, 0)
+		for _, variable := range freeVariables(conjunct, nil) {
+			if slices.Contains(variables, variable) && !slices.Contains(free, variable) {
+				free = append(free, variable)
+			}
+		}
+
+		switch len(free) {
+		case 0:
+			plan.closed = append(plan.closed, conjunct)
+		case 1:
+			plan.filters[free[0]] = append(plan.filters[free[0]], conjunct)
+		default:
+			plan.joins = append(plan.joins, joinConjunct{conjunct, free})
+		}
+	}
+
+	return plan
+}
+
+// Order groups the variables of a plan and orders every group for the
+// search, given the number of rows of every variable left by its filters.
+func (o *Optimizer) Order(plan *QueryPlan, sizes map[string]int) {
+	plan.groups = make([]*JoinGroup, 0)
+	placed := make(map[string]bool)
+	for len(placed) < len(plan.variables) {
+		group := &JoinGroup{}
+		next := o.smallest(plan.variables, sizes, func(variable string) bool { return !placed[variable] })
+		for next != "" {
+			placed[next] = true
+			group.order = append(group.order, next)
+			next = o.smallest(plan.variables, sizes, func(variable string) bool {
+				return !placed[variable] && plan.connected(variable, group.order)
+			})
+		}
+
+		group.checks = make([][]Expression, len(group.order))
+		for _, join := range plan.joins {
+			if !slices.Contains(group.order, join.variables[0]) {
+				continue
+			}
+
+			last := 0
+			for _, variable := range join.variables {
+				last = max(last, slices.Index(group.order, variable))
+			}
+
+			group.checks[last] = append(group.checks[last], join.expression)
+		}
+
+		plan.groups = append(plan.groups, group)
+	}
+}
+
+// JoinOrder orders the relations of a join so that every relation but the
+// first shares an attribute with the ones before it whenever possible, the
+// smallest ones first; the join then builds no products it can avoid. As in
+// the join itself, the attributes of a relation are those all its tuples have.
+func (o *Optimizer) JoinOrder(relations []string, calculated entity.Relations) []string {
+	join := Join{}
+	sizes := make(map[string]int, len(relations))
+	keys := make(map[string][]string, len(relations))
+	for _, name := range relations {
+		relation, exists := calculated[name]
+		if !exists {
+			return relations
+		}
+
+		sizes[name] = len(*relation)
+		for _, key := range attributes(relation) {
+			if join.hasAttribute(relation, key) {
+				keys[name] = append(keys[name], key)
+			}
+		}
+	}
+
+	ordered := make([]string, 0, len(relations))
+	joined := make(map[string]bool)
+	isPlaced := func(name string) bool { return slices.Contains(ordered, name) }
+	for len(ordered) < len(relations) {
+		next := o.smallest(relations, sizes, func(name string) bool {
+			return !isPlaced(name) && slices.ContainsFunc(keys[name], func(key string) bool { return joined[key] })
+		})
+		if next == "" {
+			next = o.smallest(relations, sizes, func(name string) bool { return !isPlaced(name) })
+		}
+
+		ordered = append(ordered, next)
+		for _, key := range keys[next] {
+			joined[key] = true
+		}
+	}
+
+	return ordered
+}
+
+// smallest returns the accepted variable with the fewest rows, the first one
+// among equals, or "" if none is accepted.
+func (o *Optimizer) smallest(variables []string, sizes map[string]int, accept func(string) bool) string {
+	result := ""
+	for _, variable := range variables {
+		if accept(variable) && (result == "" || sizes[variable] < sizes[result]) {
+			result = variable
+		}
+	}
+
+	return result
+}
+
+func (p *QueryPlan) connected(variable string, group []string) bool {
+	for _, join := range p.joins {
+		if slices.Contains(join.variables, variable) &&
+			slices.ContainsFunc(join.variables, func(other string) bool { return slices.Contains(group, other) }) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// conjuncts splits a qualification at its top-level conjunctions.
+func conjuncts(expression Expression) []Expression {
+	if expression == nil {
+		return nil
+	}
+
+	if conjunction, isBinary := expression.(*BinaryExpression); isBinary && conjunction.kind == model.CONJUNCTION.String() {
+		return append(conjuncts(conjunction.left), conjuncts(conjunction.right)...)
+	}
+
+	return []Expression{expression}
+}
+
+// freeVariables lists the variables a qualification refers to outside the
+// quantifiers and functions that bind them.
+func freeVariables(expression Expression, bound []string) []string {
+	switch node := expression.(type) {
+	case *IdentifierExpression:
+		variable := node.value
+		switch node.kind {
+		case model.ATTRIBUTE.String():
+			attr := model.Attribute{}
+			attribute, err := attr.ExtractAttribute(node.value, node.position)
+			if err != nil {
+				return nil
+			}
+
+			variable = attribute.Relation
+		case model.FREE_RELATION.String(), model.BIND_RELATION.String():
+		default:
+			return nil
+		}
+
+		if slices.Contains(bound, variable) {
+			return nil
+		}
+
+		return []string{variable}
+	case *BinaryExpression:
+		if node.kind == model.EXISTS.String() || node.kind == model.FOR_ALL.String() {
+			return freeVariables(node.right, append(slices.Clone(bound), node.left.(*IdentifierExpression).value))
+		}
+
+		return append(freeVariables(node.left, bound), freeVariables(node.right, bound)...)
+	case *UnaryExpression:
+		return freeVariables(node.expression, bound)
+	case *FunctionExpression:
+		relations := attributeRelations(node.argument)
+		if node.argument.GetKind() != model.ATTRIBUTE.String() {
+			relations = []string{node.argument.(*IdentifierExpression).value}
+		}
+
+		if node.qualification == nil {
+			return nil
+		}
+
+		return freeVariables(node.qualification, append(slices.Clone(bound), relations...))
+	case *InExpression:
+		variables := freeVariables(node.operand, bound)
+		for _, value := range node.values {
+			variables = append(variables, freeVariables(value, bound)...)
+		}
+
+		return variables
+	case *BetweenExpression:
+		variables := freeVariables(node.operand, bound)
+		variables = append(variables, freeVariables(node.lower, bound)...)
+		return append(variables, freeVariables(node.upper, bound)...)
+	case *PatternExpression:
+		return freeVariables(node.operand, bound)
+	default:
+		return nil
+	}
+}
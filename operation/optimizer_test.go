@@ -0,0 +1,114 @@
+package operation
+
+import (
+	"alpha-executor/entity"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPlanSplitsConjunctsByVariables(t *testing.T) {
+	program := parse(t, `GET W (EMP.name, DEPT.title): EMP.dept = DEPT.dept ∧ EMP.salary > 95 ∧ 1 = 1 ∧ DEPT.title ≠ "it" ∧ EMP.id < 4`)
+	get := program.body[0].(*GetHoldExpression)
+
+	optimizer := NewOptimizer()
+	plan := optimizer.Plan([]string{"EMP", "DEPT"}, get.expression)
+	if len(plan.closed) != 1 || len(plan.filters["EMP"]) != 2 || len(plan.filters["DEPT"]) != 1 || len(plan.joins) != 1 {
+		t.Fatalf("got %d closed, %d and %d filters, %d joins", len(plan.closed), len(plan.filters["EMP"]), len(plan.filters["DEPT"]), len(plan.joins))
+	}
+
+	optimizer.Order(plan, map[string]int{"EMP": 4, "DEPT": 3})
+	if len(plan.groups) != 1 || !slices.Equal(plan.groups[0].order, []string{"DEPT", "EMP"}) {
+		t.Fatalf("got groups %v, want one group DEPT, EMP", plan.groups)
+	}
+
+	if checks := plan.groups[0].checks; len(checks[0]) != 0 || len(checks[1]) != 1 {
+		t.Errorf("got checks %v, want the join once both variables are bound", checks)
+	}
+}
+
+func TestPlanSeparatesUnconnectedVariables(t *testing.T) {
+	program := parse(t, `GET W (EMP.name, DEPT.title, PROJ.code): EMP.dept = DEPT.dept`)
+	get := program.body[0].(*GetHoldExpression)
+
+	optimizer := NewOptimizer()
+	plan := optimizer.Plan([]string{"EMP", "DEPT", "PROJ"}, get.expression)
+	optimizer.Order(plan, map[string]int{"EMP": 4, "DEPT": 3, "PROJ": 2})
+	if len(plan.groups) != 2 || !slices.Equal(plan.groups[0].order, []string{"PROJ"}) {
+		t.Errorf("got %d groups, want PROJ on its own", len(plan.groups))
+	}
+}
+
+// TestOptimizedResultsStayIdentical evaluates every query once as it is and
+// once with its qualification under a double negation, which the optimizer
+// cannot split, so that it is evaluated for every combination of rows.
+func TestOptimizedResultsStayIdentical(t *testing.T) {
+	data := strings.Replace(employees, `"DEPT": [`, `"PROJ": [
+		{"code": ["p1"], "dept": ["d1"], "budget": ["10"]},
+		{"code": ["p2"], "dept": ["d3"], "budget": ["50"]}
+	],
+	"DEPT": [`, 1)
+
+	tests := []struct {
+		targets       string
+		qualification string
+	}{
+		{`EMP.name, DEPT.title`, `EMP.dept = DEPT.dept ∧ EMP.salary > 95 ∧ DEPT.title ≠ "it"`},
+		{`EMP.name, PROJ.code`, `EMP.dept = PROJ.dept ∧ PROJ.budget > EMP.salary / 10`},
+		{`EMP.name, DEPT.title, PROJ.code`, `EMP.dept = DEPT.dept ∧ PROJ.budget > 20`},
+		{`EMP.name, DEPT.title, PROJ.code`, `EMP.dept = DEPT.dept ∧ DEPT.dept = PROJ.dept ∧ EMP.id ≠ 2`},
+		{`EMP.name, DEPT.title`, `EMP.dept = DEPT.dept ∧ 1 = 2`},
+		{`EMP.name, DEPT.title`, `EMP.dept = DEPT.dept ∨ EMP.salary > 250`},
+		{`EMP.name, DEPT.title`, `EMP.dept = DEPT.dept ∧ ∃PROJ (PROJ.dept = DEPT.dept)`},
+		{`DEPT.title, COUNT(EMP WHERE EMP.dept = DEPT.dept)`, `DEPT.title ≠ "ops" ∧ COUNT(EMP WHERE EMP.dept = DEPT.dept) > 0`},
+	}
+
+	for _, test := range tests {
+		optimized := `GET W (` + test.targets + `): ` + test.qualification
+		unsplit := `GET W (` + test.targets + `): ¬¬(` + test.qualification + `)`
+		expected, _, err := run(t, data, unsplit)
+		if err != nil {
+			t.Fatalf("%s: %v", unsplit, err)
+		}
+
+		rows, _ := json.Marshal(expected["W"].Relation)
+		expectWorkspace(t, data, optimized, "W", string(rows))
+	}
+}
+
+func TestJoinOrder(t *testing.T) {
+	tests := []struct {
+		name      string
+		relations string
+		order     []string
+		expected  []string
+	}{
+		{
+			"connected relations first",
+			`{"P": [{"code": ["p1"]}], "E": [{"name": ["ann"], "dept": ["d1"]}, {"name": ["bob"], "dept": ["d1"]}, {"name": ["cid"], "dept": ["d2"]}], "D": [{"dept": ["d1"], "title": ["sales"]}, {"dept": ["d2"], "title": ["it"]}]}`,
+			[]string{"E", "D", "P"},
+			[]string{"P", "D", "E"},
+		},
+		{
+			"attributes of every tuple",
+			`{"R": [{"a": ["1"]}], "S": [{"b": ["1"]}, {"a": ["1"], "b": ["2"]}], "T": [{"a": ["1"]}, {"a": ["2"]}, {"a": ["3"]}]}`,
+			[]string{"R", "S", "T"},
+			[]string{"R", "T", "S"},
+		},
+	}
+
+	optimizer := NewOptimizer()
+	for _, test := range tests {
+		var relations entity.Relations
+		if err := json.Unmarshal([]byte(test.relations), &relations); err != nil {
+			t.Fatal(err)
+		}
+
+		for range 10 {
+			if order := optimizer.JoinOrder(test.order, relations); !slices.Equal(order, test.expected) {
+				t.Fatalf("%s: got %v, want %v", test.name, order, test.expected)
+			}
+		}
+	}
+}
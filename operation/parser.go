@@ -4,49 +4,86 @@ import (
 	"alpha-executor/entity"
 	"alpha-executor/model"
 	"fmt"
-	"log"
+	"regexp"
 	"slices"
 )
 
 type Parser struct {
 	tokens []*model.Token
+	end    entity.Position
 }
 
 func NewParser(tokens []*model.Token) *Parser {
-	return &Parser{tokens: tokens}
+	end := entity.Position{}
+	if len(tokens) > 0 {
+		end = tokens[len(tokens)-1].Position
+	}
+
+	return &Parser{tokens: tokens, end: end}
 }
 
-func (p *Parser) next() model.Token {
+func (p *Parser) next() (model.Token, error) {
 	if len(p.tokens) == 0 {
-		panic("No tokens in parser")
+		return model.Token{}, p.error(p.peek(), "unexpected end of statement")
 	}
 
 	prev := p.tokens[0]
 	p.tokens = p.tokens[1:]
-	return *prev
+	return *prev, nil
 }
 
 func (p *Parser) peek() model.Token {
 	if len(p.tokens) == 0 {
-		return model.Token{}
+		return model.Token{Type: model.EOF, Value: model.EOF.String(), Position: p.end}
 	}
 	return *p.tokens[0]
 }
 
-func (p *Parser) expect(lexType model.LexType) model.Token {
-	prev := p.next()
-	if prev.Type == model.EOF || prev.Type != lexType {
-		panic(fmt.Sprintf("Unexpected token type %s", lexType.String()))
+func (p *Parser) expect(lexType model.LexType) (model.Token, error) {
+	prev := p.peek()
+	if prev.Type != lexType {
+		return model.Token{}, p.error(prev, "expected %s, got %s", lexType.String(), p.describe(prev))
+	}
+
+	return p.next()
+}
+
+func (p *Parser) error(token model.Token, format string, args ...any) *entity.CustomError {
+	return &entity.CustomError{
+		ErrorType: entity.ResponseTypes["CE"],
+		Message:   fmt.Sprintf(format, args...),
+		Position:  token.Position,
 	}
+}
 
-	return prev
+func (p *Parser) describe(token model.Token) string {
+	switch token.Type {
+	case model.EOF:
+		return "end of statement"
+	case model.ILLEGAL:
+		return fmt.Sprintf("illegal token %q", token.Value)
+	default:
+		return fmt.Sprintf("%s %q", token.Type.String(), token.Value)
+	}
 }
 
-func (p *Parser) ParseFullExpression() Expression {
-	return p.parseExpression()
+// ParseFullExpression parses a single statement. Statements are separated by
+// the lexer, so an error abandons the rest of the statement and the caller
+// resumes with the next one.
+func (p *Parser) ParseFullExpression() (Expression, error) {
+	expression, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().Type != model.EOF {
+		return nil, p.error(p.peek(), "unexpected %s", p.describe(p.peek()))
+	}
+
+	return expression, nil
 }
 
-func (p *Parser) parseExpression() Expression {
+func (p *Parser) parseExpression() (Expression, error) {
 	switch p.peek().Type {
 	case model.GET, model.RANGE, model.HOLD, model.RELEASE, model.UPDATE, model.DELETE, model.PUT:
 		return p.parsePrimary()
@@ -55,59 +92,90 @@ func (p *Parser) parseExpression() Expression {
 	}
 }
 
-func (p *Parser) parseImplication() Expression {
-	left := p.parseDisjunction()
+func (p *Parser) parseImplication() (Expression, error) {
+	left, err := p.parseDisjunction()
+	if err != nil {
+		return nil, err
+	}
 
 	for p.peek().Type == model.IMPLICATION {
-		operator := p.next().Value
-		right := p.parseDisjunction()
+		operator, _ := p.next()
+		right, err := p.parseDisjunction()
+		if err != nil {
+			return nil, err
+		}
+
 		left = &BinaryExpression{
-			kind:     operator,
+			kind:     operator.Value,
 			left:     left,
 			right:    right,
-			position: p.peek().Position,
+			position: operator.Position,
 		}
 	}
 
-	return left
+	return left, nil
 }
 
-func (p *Parser) parseDisjunction() Expression {
-	left := p.parseConjunction()
+func (p *Parser) parseDisjunction() (Expression, error) {
+	left, err := p.parseConjunction()
+	if err != nil {
+		return nil, err
+	}
 
 	for p.peek().Type == model.DISJUNCTION {
-		operator := p.next().Value
-		right := p.parseConjunction()
+		operator, _ := p.next()
+		right, err := p.parseConjunction()
+		if err != nil {
+			return nil, err
+		}
+
 		left = &BinaryExpression{
-			kind:     operator,
+			kind:     operator.Value,
 			left:     left,
 			right:    right,
-			position: p.peek().Position,
+			position: operator.Position,
 		}
 	}
 
-	return left
+	return left, nil
 }
 
-func (p *Parser) parseConjunction() Expression {
-	left := p.parseComparison()
+func (p *Parser) parseConjunction() (Expression, error) {
+	left, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
 
 	for p.peek().Type == model.CONJUNCTION {
-		operator := p.next().Value
-		right := p.parseComparison()
+		operator, _ := p.next()
+		right, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+
 		left = &BinaryExpression{
-			kind:     operator,
+			kind:     operator.Value,
 			left:     left,
 			right:    right,
-			position: p.peek().Position,
+			position: operator.Position,
 		}
 	}
 
-	return left
+	return left, nil
 }
 
-func (p *Parser) parseComparison() Expression {
-	left := p.parsePrimary()
+func (p *Parser) parseComparison() (Expression, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+
+	switch p.peek().Type {
+	case model.IS:
+		return p.parseNullTest(left)
+	case model.NOT, model.IN, model.BETWEEN, model.LIKE, model.MATCH:
+		return p.parsePredicate(left)
+	}
 
 	operators := []model.LexType{
 		model.EQUALS,
@@ -119,106 +187,438 @@ func (p *Parser) parseComparison() Expression {
 	}
 
 	for slices.Contains(operators, p.peek().Type) {
-		operator := p.next().Value
-		right := p.parsePrimary()
+		operator, _ := p.next()
+		right, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+
 		left = &BinaryExpression{
-			kind:     operator,
+			kind:     operator.Value,
 			left:     left,
 			right:    right,
-			position: p.peek().Position,
+			position: operator.Position,
 		}
 	}
 
-	return left
+	return left, nil
 }
 
-func (p *Parser) parsePrimary() Expression {
-	parsedType := p.peek().Type
-	parsedValue := p.peek().Value
-	position := p.peek().Position
-	switch parsedType {
-	case model.ATTRIBUTE, model.FREE_RELATION, model.BIND_RELATION, model.CONSTANT, model.INTEGER, model.DATE:
-		token := p.next()
-		return &IdentifierExpression{parsedType.String(), token.Value, token.Position}
+// parseNullTest parses the rest of `operand IS [NOT] NULL`.
+func (p *Parser) parseNullTest(operand Expression) (Expression, error) {
+	is, _ := p.next()
+	kind := model.IS_NULL
+	if p.peek().Type == model.NOT {
+		p.next()
+		kind = model.IS_NOT_NULL
+	}
+
+	if _, err := p.expect(model.NULL); err != nil {
+		return nil, err
+	}
+
+	return &UnaryExpression{kind.String(), operand, is.Position}, nil
+}
+
+// parsePredicate parses the rest of `operand [NOT] IN (values)`,
+// `operand [NOT] BETWEEN lower AND upper`, `operand [NOT] LIKE "pattern"`
+// and `operand [NOT] ~ "regexp"`.
+func (p *Parser) parsePredicate(operand Expression) (Expression, error) {
+	not := p.peek()
+	if not.Type == model.NOT {
+		p.next()
+	}
+
+	operator, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	var predicate Expression
+	switch operator.Type {
+	case model.IN:
+		values, err := p.parseRelations()
+		if err != nil {
+			return nil, err
+		}
+
+		predicate = &InExpression{operator.Type.String(), operand, values, operator.Position}
+	case model.BETWEEN:
+		lower, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err = p.expect(model.AND); err != nil {
+			return nil, err
+		}
+
+		upper, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+
+		predicate = &BetweenExpression{operator.Type.String(), operand, lower, upper, operator.Position}
+	case model.LIKE, model.MATCH:
+		if predicate, err = p.parsePattern(operand, operator); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, p.error(operator, "expected IN, BETWEEN, LIKE or ~, got %s", p.describe(operator))
+	}
+
+	if not.Type == model.NOT {
+		return &UnaryExpression{model.NEGATION.String(), predicate, not.Position}, nil
+	}
+
+	return predicate, nil
+}
+
+func (p *Parser) parsePattern(operand Expression, operator model.Token) (Expression, error) {
+	token := p.peek()
+	if token.Type != model.CONSTANT && token.Type != model.DATE {
+		return nil, p.error(token, "expected pattern string, got %s", p.describe(token))
+	}
+
+	p.next()
+	expression := token.Value
+	if operator.Type == model.LIKE {
+		expression = likeToRegexp(token.Value)
+	}
+
+	matcher, err := regexp.Compile(expression)
+	if err != nil {
+		return nil, p.error(token, "invalid pattern %q: %s", token.Value, err)
+	}
+
+	pattern := &IdentifierExpression{token.Type.String(), token.Value, token.Position}
+	return &PatternExpression{operator.Type.String(), operand, pattern, matcher, operator.Position}, nil
+}
+
+func (p *Parser) parseAdditive() (Expression, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().Type == model.PLUS || p.peek().Type == model.MINUS {
+		operator, _ := p.next()
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &BinaryExpression{
+			kind:     operator.Value,
+			left:     left,
+			right:    right,
+			position: operator.Position,
+		}
+	}
+
+	return left, nil
+}
+
+func (p *Parser) parseMultiplicative() (Expression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().Type == model.MULTIPLY || p.peek().Type == model.DIVIDE {
+		operator, _ := p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &BinaryExpression{
+			kind:     operator.Value,
+			left:     left,
+			right:    right,
+			position: operator.Position,
+		}
+	}
+
+	return left, nil
+}
+
+func (p *Parser) parseUnary() (Expression, error) {
+	if p.peek().Type != model.MINUS {
+		return p.parsePrimary()
+	}
+
+	operator, _ := p.next()
+	expression, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &UnaryExpression{model.UNARY_MINUS.String(), expression, operator.Position}, nil
+}
+
+func (p *Parser) parsePrimary() (Expression, error) {
+	token := p.peek()
+	position := token.Position
+	switch token.Type {
+	case model.ATTRIBUTE, model.FREE_RELATION, model.BIND_RELATION, model.CONSTANT, model.INTEGER, model.FLOAT, model.DATE, model.NULL:
+		p.next()
+		return &IdentifierExpression{token.Type.String(), token.Value, token.Position}, nil
 	case model.EXISTS, model.FOR_ALL:
 		p.next()
-		return &BinaryExpression{parsedType.String(), p.parsePrimary(), p.parseComparison(), position}
+		variable, err := p.parseRelation()
+		if err != nil {
+			return nil, err
+		}
+
+		expression, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+
+		return &BinaryExpression{token.Type.String(), variable, expression, position}, nil
 	case model.NEGATION:
 		p.next()
-		return &UnaryExpression{parsedType.String(), p.parseComparison(), position}
+		expression, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+
+		return &UnaryExpression{token.Type.String(), expression, position}, nil
 	case model.LEFT_PARENTHESIS:
 		p.next()
-		value := p.parseImplication()
-		p.expect(model.RIGHT_PARENTHESIS)
-		return value
+		value, err := p.parseImplication()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err = p.expect(model.RIGHT_PARENTHESIS); err != nil {
+			return nil, err
+		}
+
+		return value, nil
 	case model.GET, model.HOLD:
 		p.next()
-		variable := p.parsePrimary()
-		rows, relations := p.parseRowNumAndRelation()
-		return &GetHoldExpression{parsedType.String(), variable, rows, relations,
-			p.parseImplication(), p.parseSort(), position}
-	case model.COMMA:
-		p.next()
-		return p.parsePrimary()
+		variable, err := p.parseRelation()
+		if err != nil {
+			return nil, err
+		}
+
+		rows, relations, err := p.parseRowNumAndRelation()
+		if err != nil {
+			return nil, err
+		}
+
+		var expression Expression
+		if next := p.peek().Type; next != model.EOF && next != model.UP && next != model.DOWN {
+			if expression, err = p.parseImplication(); err != nil {
+				return nil, err
+			}
+		}
+
+		sort, err := p.parseSort()
+		if err != nil {
+			return nil, err
+		}
+
+		return &GetHoldExpression{token.Type.String(), variable, rows, relations, expression, sort, position}, nil
 	case model.RANGE:
 		p.next()
-		return &RangeExpression{parsedType.String(), p.parsePrimary(), p.parsePrimary(), position}
+		relation, err := p.parseRelation()
+		if err != nil {
+			return nil, err
+		}
+
+		variable, err := p.parseRelation()
+		if err != nil {
+			return nil, err
+		}
+
+		return &RangeExpression{token.Type.String(), relation, variable, position}, nil
 	case model.PUT:
 		p.next()
-		variable := p.parsePrimary()
-		_, relations := p.parseRowNumAndRelation()
-		return &PutExpression{parsedType.String(), variable, relations, position}
+		variable, err := p.parseRelation()
+		if err != nil {
+			return nil, err
+		}
+
+		_, relations, err := p.parseRowNumAndRelation()
+		if err != nil {
+			return nil, err
+		}
+
+		return &PutExpression{token.Type.String(), variable, relations, position}, nil
 	case model.LOGIC_START:
 		p.next()
 		return p.parseImplication()
-	case model.DOWN, model.UP, model.RELEASE, model.UPDATE, model.DELETE:
+	case model.COUNT, model.TOTAL, model.MAX, model.MIN, model.AVG:
+		return p.parseFunction()
+	case model.RELEASE, model.UPDATE, model.DELETE:
 		p.next()
-		return &UnaryExpression{parsedType.String(), p.parsePrimary(), position}
+		expression, err := p.parseRelation()
+		if err != nil {
+			return nil, err
+		}
+
+		return &UnaryExpression{token.Type.String(), expression, position}, nil
 	default:
-		panic(fmt.Sprintf("Unhandled type %s with value %s", parsedType.String(), parsedValue))
+		return nil, p.error(token, "unexpected %s", p.describe(token))
 	}
 }
 
-func (p *Parser) parseRowNumAndRelation() (Expression, []Expression) {
+func (p *Parser) parseFunction() (Expression, error) {
+	token, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = p.expect(model.LEFT_PARENTHESIS); err != nil {
+		return nil, err
+	}
+
+	argument := p.peek()
+	if argument.Type != model.ATTRIBUTE && argument.Type != model.FREE_RELATION && argument.Type != model.BIND_RELATION {
+		return nil, p.error(argument, "expected relation or attribute, got %s", p.describe(argument))
+	}
+
+	p.next()
+	function := &FunctionExpression{
+		kind:     token.Type.String(),
+		argument: &IdentifierExpression{argument.Type.String(), argument.Value, argument.Position},
+		position: token.Position,
+	}
+
+	if p.peek().Type == model.WHERE {
+		p.next()
+		if function.qualification, err = p.parseImplication(); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err = p.expect(model.RIGHT_PARENTHESIS); err != nil {
+		return nil, err
+	}
+
+	return function, nil
+}
+
+func (p *Parser) parseRelation() (Expression, error) {
+	token := p.peek()
+	if token.Type != model.FREE_RELATION && token.Type != model.BIND_RELATION {
+		return nil, p.error(token, "expected relation, got %s", p.describe(token))
+	}
+
+	p.next()
+	return &IdentifierExpression{token.Type.String(), token.Value, token.Position}, nil
+}
+
+func (p *Parser) parseRowNumAndRelation() (Expression, []Expression, error) {
 	if p.peek().Type != model.LEFT_PARENTHESIS {
-		panic(fmt.Sprintf("Unexpected type %s", p.peek().Type))
+		return nil, nil, p.error(p.peek(), "expected %s, got %s", model.LEFT_PARENTHESIS.String(), p.describe(p.peek()))
+	}
+
+	position := p.peek().Position
+	row, err := p.parseRelations()
+	if err != nil {
+		return nil, nil, err
 	}
 
-	row := p.parseRelations()
 	if len(row) > 0 && row[0].GetKind() != model.INTEGER.String() {
-		return &IdentifierExpression{model.NULL.String(), model.NULL.String(), p.peek().Position}, row
+		return nil, row, nil
 	} else if len(row) == 0 {
-		log.Fatal(fmt.Sprintf("No parameters detected on %d:%d", p.peek().Position.Line, p.peek().Position.Column))
+		return nil, nil, &entity.CustomError{
+			ErrorType: entity.ResponseTypes["CE"],
+			Message:   "no parameters detected",
+			Position:  position,
+		}
+	}
+
+	relations, err := p.parseRelations()
+	if err != nil {
+		return nil, nil, err
 	}
 
-	relations := p.parseRelations()
-	return row[0], relations
+	return row[0], relations, nil
 }
 
-func (p *Parser) parseRelations() []Expression {
-	p.next()
-	relations := []Expression{p.parsePrimary()}
+func (p *Parser) parseRelations() ([]Expression, error) {
+	if _, err := p.expect(model.LEFT_PARENTHESIS); err != nil {
+		return nil, err
+	}
+
+	relation, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+
+	relations := []Expression{relation}
 	for p.peek().Type == model.COMMA {
-		relations = append(relations, p.parsePrimary())
+		p.next()
+		if relation, err = p.parseAdditive(); err != nil {
+			return nil, err
+		}
+
+		relations = append(relations, relation)
+	}
+
+	if _, err = p.expect(model.RIGHT_PARENTHESIS); err != nil {
+		return nil, err
 	}
-	p.expect(model.RIGHT_PARENTHESIS)
-	return relations
+
+	return relations, nil
 }
 
-func (p *Parser) parseSort() Expression {
-	if len(p.tokens) == 0 {
-		return &UnaryExpression{
-			kind:       model.NULL.String(),
-			expression: nil,
-			position:   entity.Position{},
-		}
+func (p *Parser) parseSort() ([]Expression, error) {
+	sort := make([]Expression, 0)
+	if p.peek().Type == model.EOF {
+		return sort, nil
 	}
 
-	return p.parsePrimary()
+	direction := p.peek()
+	if direction.Type != model.UP && direction.Type != model.DOWN {
+		return nil, p.error(direction, "unexpected %s", p.describe(direction))
+	}
+
+	for {
+		if p.peek().Type == model.UP || p.peek().Type == model.DOWN {
+			direction, _ = p.next()
+		}
+
+		attribute, err := p.expect(model.ATTRIBUTE)
+		if err != nil {
+			return nil, err
+		}
+
+		sort = append(sort, &UnaryExpression{
+			kind:       direction.Type.String(),
+			expression: &IdentifierExpression{attribute.Type.String(), attribute.Value, attribute.Position},
+			position:   direction.Position,
+		})
+
+		if p.peek().Type != model.COMMA {
+			return sort, nil
+		}
+
+		p.next()
+	}
 }
 
-func (p *Parser) parseAssigment() Expression {
-	expression := p.parseComparison()
-	expression.(*BinaryExpression).kind = model.ASSIGN.String()
-	return expression
+func (p *Parser) parseAssigment() (Expression, error) {
+	position := p.peek().Position
+	expression, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+
+	assignment, ok := expression.(*BinaryExpression)
+	if !ok || assignment.kind != model.EQUALS.String() {
+		return nil, &entity.CustomError{
+			ErrorType: entity.ResponseTypes["CE"],
+			Message:   "expected statement or assignment",
+			Position:  position,
+		}
+	}
+
+	assignment.kind = model.ASSIGN.String()
+	return assignment, nil
 }
@@ -0,0 +1,68 @@
+package operation
+
+import (
+	"alpha-executor/entity"
+	"bufio"
+	"strings"
+	"testing"
+)
+
+type syntaxError struct {
+	message string
+	line    int
+	column  int
+}
+
+func expectSyntaxErrors(t *testing.T, source string, expected ...syntaxError) Program {
+	t.Helper()
+	program, errors := GenerateAST(bufio.NewReader(strings.NewReader(source)))
+	if len(errors) != len(expected) {
+		t.Fatalf("%q: got errors %v, want %d", source, entity.CustomErrors(errors), len(expected))
+	}
+
+	for i, err := range errors {
+		if err.ErrorType != entity.ResponseTypes["CE"] {
+			t.Errorf("%q: got verdict %s, want %s", source, err.ErrorType, entity.ResponseTypes["CE"])
+		}
+
+		want := expected[i]
+		if err.Message != want.message || err.Position.Line != want.line || err.Position.Column != want.column {
+			t.Errorf("%q: got %q at %d:%d, want %q at %d:%d", source, err.Message,
+				err.Position.Line, err.Position.Column, want.message, want.line, want.column)
+		}
+	}
+
+	return program
+}
+
+func TestParseErrorPositions(t *testing.T) {
+	tests := []struct {
+		source   string
+		expected syntaxError
+	}{
+		{"GET W (EMP.name): EMP.name = ", syntaxError{"unexpected end of statement", 1, 28}},
+		{"GET W (EMP.name EMP.name = 1", syntaxError{`expected ), got ATTRIBUTE "EMP.name"`, 1, 17}},
+		{"GET W EMP.name: EMP.id = 1", syntaxError{`expected (, got ATTRIBUTE "EMP.name"`, 1, 7}},
+		{"GET (EMP.name): EMP.id = 1", syntaxError{`expected relation, got ( "("`, 1, 5}},
+		{"GET W (): EMP.id = 1", syntaxError{`unexpected ) ")"`, 1, 8}},
+		{"EMP.id", syntaxError{"expected statement or assignment", 1, 1}},
+		{`GET W (EMP.id): EMP.name = "ann`, syntaxError{"unterminated string literal", 1, 28}},
+	}
+
+	for _, test := range tests {
+		expectSyntaxErrors(t, test.source, test.expected)
+	}
+}
+
+func TestParseRecoversAtSemicolon(t *testing.T) {
+	source := "RANGE EMP;\nGET W (EMP.name): (EMP.id = 1;\nGET W (EMP.name): EMP.id = 1;\nHOLD H EMP"
+	program := expectSyntaxErrors(t, source,
+		syntaxError{"expected relation, got end of statement", 1, 7},
+		syntaxError{"expected ), got end of statement", 2, 29},
+		syntaxError{`expected (, got FREE_RELATION "EMP"`, 4, 8},
+	)
+
+	if len(program.body) != 1 {
+		t.Errorf("got %d statements, want the one valid GET", len(program.body))
+	}
+}
@@ -0,0 +1,118 @@
+package operation
+
+import (
+	"alpha-executor/model"
+	"regexp"
+	"strings"
+)
+
+// evaluateIn is TRUE if the operand equals some of the listed values and
+// UNKNOWN if it equals none of them but either side is NULL.
+func (i *Interpreter) evaluateIn(expression *InExpression) (TruthValue, error) {
+	operand, err := i.evaluateOperand(expression.operand)
+	if err != nil {
+		return FALSE, err
+	}
+
+	comparison := NewComparison(i.repository)
+	result := FALSE
+	for _, valueExpression := range expression.values {
+		values, err := i.evaluateOperand(valueExpression)
+		if err != nil {
+			return FALSE, err
+		}
+
+		equals, err := comparison.CompareValues(model.EQUALS.String(), operand, values, expression.position)
+		if err != nil {
+			return FALSE, err
+		}
+
+		if result = result.Or(equals); result == TRUE {
+			return TRUE, nil
+		}
+	}
+
+	return result, nil
+}
+
+// evaluateBetween is TRUE if some value of the operand lies within the
+// inclusive bounds.
+func (i *Interpreter) evaluateBetween(expression *BetweenExpression) (TruthValue, error) {
+	operand, err := i.evaluateOperand(expression.operand)
+	if err != nil {
+		return FALSE, err
+	}
+
+	lower, err := i.evaluateOperand(expression.lower)
+	if err != nil {
+		return FALSE, err
+	}
+
+	upper, err := i.evaluateOperand(expression.upper)
+	if err != nil {
+		return FALSE, err
+	}
+
+	if len(operand) == 0 {
+		return UNKNOWN, nil
+	}
+
+	comparison := NewComparison(i.repository)
+	result := FALSE
+	for _, value := range operand {
+		aboveLower, err := comparison.CompareValues(model.GREATER_THAN_EQUALS.String(), []string{value}, lower, expression.position)
+		if err != nil {
+			return FALSE, err
+		}
+
+		belowUpper, err := comparison.CompareValues(model.LESS_THAN_EQUALS.String(), []string{value}, upper, expression.position)
+		if err != nil {
+			return FALSE, err
+		}
+
+		if result = result.Or(aboveLower.And(belowUpper)); result == TRUE {
+			return TRUE, nil
+		}
+	}
+
+	return result, nil
+}
+
+func (i *Interpreter) evaluatePattern(expression *PatternExpression) (TruthValue, error) {
+	operand, err := i.evaluateOperand(expression.operand)
+	if err != nil {
+		return FALSE, err
+	}
+
+	if len(operand) == 0 {
+		return UNKNOWN, nil
+	}
+
+	for _, value := range operand {
+		if expression.matcher.MatchString(value) {
+			return TRUE, nil
+		}
+	}
+
+	return FALSE, nil
+}
+
+// likeToRegexp translates a LIKE pattern, where % matches any sequence of
+// characters and _ a single character, into an anchored regular expression.
+func likeToRegexp(pattern string) string {
+	var builder strings.Builder
+	builder.WriteString("(?s)^")
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			builder.WriteString(".*")
+		case '_':
+			builder.WriteString(".")
+		default:
+			builder.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	builder.WriteString("$")
+	return builder.String()
+}
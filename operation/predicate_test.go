@@ -0,0 +1,53 @@
+package operation
+
+import "testing"
+
+func TestSetAndPatternPredicates(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{`GET W (EMP.id): EMP.dept IN ("d1", "d3")`, `[{"id": ["1"]}, {"id": ["2"]}, {"id": ["4"]}]`},
+		{`GET W (EMP.id): EMP.dept NOT IN ("d1", "d3")`, `[{"id": ["3"]}]`},
+		{`GET W (EMP.id): EMP.salary IN (90, 300)`, `[{"id": ["3"]}, {"id": ["4"]}]`},
+		{`GET W (EMP.id): EMP.salary BETWEEN 90 AND 100`, `[{"id": ["1"]}, {"id": ["3"]}]`},
+		{`GET W (EMP.id): EMP.salary NOT BETWEEN 90 AND 100`, `[{"id": ["2"]}, {"id": ["4"]}]`},
+		{`GET W (EMP.id): EMP.name LIKE "a%"`, `[{"id": ["1"]}, {"id": ["4"]}]`},
+		{`GET W (EMP.id): EMP.name LIKE "_i_"`, `[{"id": ["3"]}]`},
+		{`GET W (EMP.id): EMP.name NOT LIKE "%n"`, `[{"id": ["2"]}, {"id": ["3"]}]`},
+		{`GET W (EMP.id): EMP.name ~ "^[bc]"`, `[{"id": ["2"]}, {"id": ["3"]}]`},
+	}
+
+	for _, test := range tests {
+		expectWorkspace(t, employees, test.query, "W", test.expected)
+	}
+}
+
+func TestPredicatesOverNull(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{`GET W (EMP.id): EMP.dept NOT IN ("d2")`, `[{"id": ["1"]}, {"id": ["2"]}]`},
+		{`GET W (EMP.id): EMP.salary NOT BETWEEN 0 AND 95`, `[{"id": ["1"]}]`},
+		{`GET W (EMP.id): ¬(EMP.dept LIKE "d%")`, `[]`},
+	}
+
+	for _, test := range tests {
+		expectWorkspace(t, nullEmployees, test.query, "W", test.expected)
+	}
+}
+
+func TestLikeToRegexp(t *testing.T) {
+	tests := map[string]string{
+		"a%":   "(?s)^a.*$",
+		"_b":   "(?s)^.b$",
+		"a.b%": `(?s)^a\.b.*$`,
+	}
+
+	for pattern, expected := range tests {
+		if got := likeToRegexp(pattern); got != expected {
+			t.Errorf("%s: got %s, want %s", pattern, got, expected)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package operation
+
+import (
+	"alpha-executor/model"
+	"fmt"
+	"strings"
+)
+
+// Printer turns an AST back into ALPHA source in canonical form: one
+// statement per line, Unicode operators, and every compound operand
+// parenthesised, so that the output does not depend on operator precedence.
+type Printer struct {
+	builder strings.Builder
+}
+
+func NewPrinter() *Printer {
+	return &Printer{}
+}
+
+var printedOperators = map[string]string{
+	model.NOT_EQUALS.String():          "≠",
+	model.LESS_THAN_EQUALS.String():    "≤",
+	model.GREATER_THAN_EQUALS.String(): "≥",
+	model.IMPLICATION.String():         "→",
+}
+
+func (p *Printer) Print(program *Program) string {
+	p.builder.Reset()
+	for _, statement := range program.body {
+		p.expression(statement)
+		p.builder.WriteString(";\n")
+	}
+
+	return p.builder.String()
+}
+
+// PrintExpression formats a single statement, qualification or operand.
+func (p *Printer) PrintExpression(expression Expression) string {
+	p.builder.Reset()
+	p.expression(expression)
+	return p.builder.String()
+}
+
+func (p *Printer) expression(expression Expression) {
+	switch node := expression.(type) {
+	case *GetHoldExpression:
+		p.getHold(node)
+	case *RangeExpression:
+		p.write("%s %s %s", node.kind, p.identifier(node.relation), p.identifier(node.variable))
+	case *PutExpression:
+		p.write("%s %s ", node.kind, p.identifier(node.variable))
+		p.list(node.relations)
+	case *IdentifierExpression:
+		p.builder.WriteString(p.identifier(node))
+	case *FunctionExpression:
+		p.write("%s(", node.kind)
+		p.expression(node.argument)
+		if node.qualification != nil {
+			p.builder.WriteString(" WHERE ")
+			p.expression(node.qualification)
+		}
+		p.builder.WriteString(")")
+	case *BinaryExpression:
+		p.binary(node)
+	case *UnaryExpression:
+		p.unary(node)
+	case *InExpression:
+		p.operand(node.operand)
+		p.write(" %s ", node.kind)
+		p.list(node.values)
+	case *BetweenExpression:
+		p.operand(node.operand)
+		p.write(" %s ", node.kind)
+		p.operand(node.lower)
+		p.write(" %s ", model.AND.String())
+		p.operand(node.upper)
+	case *PatternExpression:
+		p.operand(node.operand)
+		p.write(" %s %s", node.kind, p.identifier(node.pattern))
+	default:
+		p.builder.WriteString(expression.GetKind())
+	}
+}
+
+func (p *Printer) getHold(node *GetHoldExpression) {
+	p.write("%s %s ", node.kind, p.identifier(node.variable))
+	if node.rows != nil {
+		p.write("(%s) ", p.identifier(node.rows))
+	}
+
+	p.list(node.relations)
+	if node.expression != nil {
+		p.builder.WriteString(": ")
+		p.expression(node.expression)
+	}
+
+	for i, key := range node.sort {
+		if i > 0 {
+			p.builder.WriteString(",")
+		}
+
+		sortKey := key.(*UnaryExpression)
+		p.write(" %s ", sortKey.kind)
+		p.expression(sortKey.expression)
+	}
+}
+
+func (p *Printer) binary(node *BinaryExpression) {
+	switch node.kind {
+	case model.EXISTS.String(), model.FOR_ALL.String():
+		p.write("%s%s(", node.kind, p.identifier(node.left))
+		p.expression(node.right)
+		p.builder.WriteString(")")
+	case model.ASSIGN.String():
+		p.expression(node.left)
+		p.write(" %s ", model.EQUALS.String())
+		p.expression(node.right)
+	default:
+		operator := node.kind
+		if printed, exists := printedOperators[operator]; exists {
+			operator = printed
+		}
+
+		p.operand(node.left)
+		p.write(" %s ", operator)
+		p.operand(node.right)
+	}
+}
+
+func (p *Printer) unary(node *UnaryExpression) {
+	switch node.kind {
+	case model.NEGATION.String():
+		p.builder.WriteString(node.kind)
+		p.parenthesised(node.expression)
+	case model.UNARY_MINUS.String():
+		p.builder.WriteString(model.MINUS.String())
+		p.operand(node.expression)
+	case model.IS_NULL.String(), model.IS_NOT_NULL.String():
+		p.operand(node.expression)
+		p.write(" %s", node.kind)
+	default:
+		p.write("%s ", node.kind)
+		p.expression(node.expression)
+	}
+}
+
+// operand writes an operand of an operator, parenthesising it unless it is
+// a name, a constant, a function call, a negation or a quantifier, which
+// delimit themselves.
+func (p *Printer) operand(expression Expression) {
+	switch expression.GetKind() {
+	case model.NEGATION.String(), model.EXISTS.String(), model.FOR_ALL.String():
+		p.expression(expression)
+		return
+	}
+
+	switch expression.(type) {
+	case *IdentifierExpression, *FunctionExpression:
+		p.expression(expression)
+	default:
+		p.parenthesised(expression)
+	}
+}
+
+func (p *Printer) parenthesised(expression Expression) {
+	p.builder.WriteString("(")
+	p.expression(expression)
+	p.builder.WriteString(")")
+}
+
+func (p *Printer) list(expressions []Expression) {
+	p.builder.WriteString("(")
+	for i, expression := range expressions {
+		if i > 0 {
+			p.builder.WriteString(", ")
+		}
+
+		p.expression(expression)
+	}
+	p.builder.WriteString(")")
+}
+
+func (p *Printer) identifier(expression Expression) string {
+	identifier := expression.(*IdentifierExpression)
+	switch identifier.kind {
+	case model.CONSTANT.String(), model.DATE.String():
+		return quote(identifier.value)
+	default:
+		return identifier.value
+	}
+}
+
+func (p *Printer) write(format string, args ...any) {
+	_, _ = fmt.Fprintf(&p.builder, format, args...)
+}
+
+// quote writes a string constant with the escapes the lexer understands.
+func quote(value string) string {
+	var builder strings.Builder
+	builder.WriteByte('"')
+	for _, r := range value {
+		switch r {
+		case '"', '\\':
+			builder.WriteByte('\\')
+			builder.WriteRune(r)
+		case '\n':
+			builder.WriteString(`\n`)
+		case '\t':
+			builder.WriteString(`\t`)
+		case '\r':
+			builder.WriteString(`\r`)
+		default:
+			if r < ' ' {
+				_, _ = fmt.Fprintf(&builder, `\u%04x`, r)
+				continue
+			}
+
+			builder.WriteRune(r)
+		}
+	}
+	builder.WriteByte('"')
+
+	return builder.String()
+}
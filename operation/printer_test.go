@@ -0,0 +1,72 @@
+package operation
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+func parse(t *testing.T, source string) Program {
+	t.Helper()
+	program, errors := GenerateAST(bufio.NewReader(strings.NewReader(source)))
+	if len(errors) > 0 {
+		t.Fatalf("%s: %v", source, errors[0])
+	}
+
+	return program
+}
+
+// TestPrintRoundTrip parses every program, prints it, parses the printed
+// source and prints it again: both parses have to give the same canonical
+// form, so the printer loses nothing the parser reads.
+func TestPrintRoundTrip(t *testing.T) {
+	programs := []string{
+		`GET W (EMP)`,
+		`GET W (EMP.name, EMP.salary): EMP.salary > 100 ∧ EMP.dept = "d1" DOWN EMP.salary, UP EMP.name`,
+		`GET W (3) (EMP.name) UP EMP.name`,
+		`RANGE DEPT D; GET W (EMP.name): ∃D (D.dept = EMP.dept ∧ D.title ≠ "it")`,
+		`RANGE EMP E; GET W (DEPT.title): ∀E (E.dept = DEPT.dept → E.salary ≥ 100)`,
+		`GET W (EMP.name): ¬(EMP.salary ≤ 100 ∨ EMP.dept = "d2")`,
+		`GET W (EMP.name): EMP.salary + 1 * 2 > -EMP.id - 3 / 4`,
+		`GET W (EMP.name, EMP.salary * 2 + 1): (EMP.salary + 1) * 2 > 10`,
+		`GET W (EMP.name): EMP.dept IN ("d1", "d2") ∧ EMP.salary BETWEEN 90 AND 200`,
+		`GET W (EMP.name): EMP.name LIKE "a%" ∨ EMP.name ~ "^b.*"`,
+		`GET W (EMP.name): EMP.salary IS NULL ∨ EMP.dept IS NOT NULL`,
+		`GET W (EMP.name): EMP.hired < 2000-01-01`,
+		`GET W (EMP.name): EMP.name = "quote \" and backslash \\"`,
+		`GET W (COUNT(EMP), TOTAL(EMP.salary WHERE EMP.dept = "d1"))`,
+		`RANGE EMP E; GET W (DEPT.dept): COUNT(E WHERE E.dept = DEPT.dept) > 1`,
+		`HOLD H (EMP): EMP.id = 1; H.salary = 10; UPDATE H; RELEASE H`,
+		`HOLD H (EMP.name): EMP.id = 1; DELETE H`,
+		`GET W (EMP); PUT W (DEPT)`,
+	}
+
+	printer := NewPrinter()
+	for _, source := range programs {
+		program := parse(t, source)
+		printed := printer.Print(&program)
+		reparsed := parse(t, printed)
+		if again := printer.Print(&reparsed); again != printed {
+			t.Errorf("%s: printed\n%s\nreprinted\n%s", source, printed, again)
+		}
+	}
+}
+
+func TestPrintCanonicalForm(t *testing.T) {
+	tests := []struct {
+		source   string
+		expected string
+	}{
+		{`GET W(EMP.name):EMP.salary+1*2>-EMP.id -3/4`, "GET W (EMP.name): (EMP.salary + (1 * 2)) > ((-EMP.id) - (3 / 4));\n"},
+		{`RANGE EMP E; GET W (DEPT.title): ∀E (E.dept = DEPT.dept -> E.salary >= 100)`,
+			"RANGE EMP E;\nGET W (DEPT.title): ∀E((E.dept = DEPT.dept) → (E.salary ≥ 100));\n"},
+		{`GET W (EMP.name): A.x != 1 /* comment */`, "GET W (EMP.name): A.x ≠ 1;\n"},
+	}
+
+	for _, test := range tests {
+		program := parse(t, test.source)
+		if printed := NewPrinter().Print(&program); printed != test.expected {
+			t.Errorf("%s: got %q, want %q", test.source, printed, test.expected)
+		}
+	}
+}
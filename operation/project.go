@@ -9,42 +9,80 @@ import (
 type Projection struct {
 }
 
-func (*Projection) Execute(relation entity.Pair[string, *entity.Relation], attributes []string, position entity.Position) (*entity.Relation, error) {
+func (p *Projection) Execute(relation entity.Pair[string, *entity.Relation], attributes []string, position entity.Position) (*entity.Relation, error) {
 	projected := make(entity.Relation)
-	attr := model.Attribute{}
 	for row := range *relation.Right {
-		newRow := make(entity.RowMap)
-		for _, attribute := range attributes {
-			slicedAttribute, err := attr.ExtractAttribute(attribute, position)
-			if err != nil {
-				continue
-			}
+		newRow, err := p.projectRow(row, attributes, position)
+		if err != nil {
+			return nil, err
+		}
 
-			values, exists := (*row)[slicedAttribute.Attribute]
-			if !exists {
-				return nil, &entity.CustomError{
-					ErrorType: entity.ResponseTypes["CE"],
-					Message:   fmt.Sprintf("attribute %s doesn't exist", attribute),
-					Position:  position,
-				}
+		duplicate := false
+		for projectedRow := range projected {
+			if projectedRow.RowsEqual(newRow) {
+				duplicate = true
+				break
 			}
+		}
+
+		if !duplicate {
+			projected[newRow] = struct{}{}
+		}
+	}
+	return &projected, nil
+}
 
-			data := newRow[slicedAttribute.Attribute]
-			data = append(data, values...)
-			newRow[slicedAttribute.Attribute] = data
+// ExecuteOrdered projects rows keeping their order; of several rows with
+// the same projection only the first one is kept.
+func (p *Projection) ExecuteOrdered(rows []*entity.RowMap, attributes []string, position entity.Position) ([]*entity.RowMap, error) {
+	projected := make([]*entity.RowMap, 0, len(rows))
+	for _, row := range rows {
+		newRow, err := p.projectRow(row, attributes, position)
+		if err != nil {
+			return nil, err
 		}
 
 		duplicate := false
-		for projectedRow := range projected {
-			if projectedRow.RowsEqual(&newRow) {
+		for _, projectedRow := range projected {
+			if projectedRow.RowsEqual(newRow) {
 				duplicate = true
 				break
 			}
 		}
 
 		if !duplicate {
-			projected[&newRow] = struct{}{}
+			projected = append(projected, newRow)
 		}
 	}
-	return &projected, nil
+	return projected, nil
+}
+
+func (*Projection) projectRow(row *entity.RowMap, attributes []string, position entity.Position) (*entity.RowMap, error) {
+	attr := model.Attribute{}
+	newRow := make(entity.RowMap)
+	for _, attribute := range attributes {
+		if values, exists := (*row)[attribute]; exists {
+			newRow[attribute] = append(newRow[attribute], values...)
+			continue
+		}
+
+		slicedAttribute, err := attr.ExtractAttribute(attribute, position)
+		if err != nil {
+			continue
+		}
+
+		values, exists := (*row)[slicedAttribute.Attribute]
+		if !exists {
+			return nil, &entity.CustomError{
+				ErrorType: entity.ResponseTypes["CE"],
+				Message:   fmt.Sprintf("attribute %s doesn't exist", attribute),
+				Position:  position,
+			}
+		}
+
+		data := newRow[slicedAttribute.Attribute]
+		data = append(data, values...)
+		newRow[slicedAttribute.Attribute] = data
+	}
+	return &newRow, nil
 }
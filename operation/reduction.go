@@ -0,0 +1,293 @@
+package operation
+
+import (
+	"alpha-executor/entity"
+	"alpha-executor/model"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// Reducer translates ALPHA queries into relational algebra by Codd's
+// reduction algorithm:
+//
+//  1. the qualification is brought into prenex normal form Q1X1…QnXn(M),
+//     renaming quantified variables that are bound more than once;
+//  2. the free variables of the target list and the quantified variables
+//     are combined by a product of their ranges, each renamed by ρ;
+//  3. σ[M] selects the tuples satisfying the matrix;
+//  4. the quantifiers are eliminated from the innermost one outwards,
+//     ∃X by projecting X away and ∀X by dividing by the range of X;
+//  5. π projects the result onto the target list.
+//
+// Like Codd's algorithm it assumes that ranges are not empty: over an empty
+// range ∀ is vacuously true, while the division yields no tuples.
+type Reducer struct {
+	ranges map[string]string
+}
+
+// quantifier is an element of the prefix of a prenex formula.
+type quantifier struct {
+	kind     string
+	variable string
+	relation string
+}
+
+func NewReducer() *Reducer {
+	return &Reducer{
+		ranges: make(map[string]string),
+	}
+}
+
+func (r *Reducer) Reduce(program *Program) ([]*AlgebraStatement, error) {
+	statements := make([]*AlgebraStatement, 0, len(program.body))
+	for _, expression := range program.body {
+		switch statement := expression.(type) {
+		case *RangeExpression:
+			variable := statement.variable.(*IdentifierExpression).value
+			relation := r.resolve(statement.relation.(*IdentifierExpression).value)
+			r.ranges[variable] = relation
+			statements = append(statements, &AlgebraStatement{statement.kind, variable, &RelationNode{name: relation}})
+		case *GetHoldExpression:
+			if statement.kind != model.GET.String() {
+				return nil, r.unsupported(statement.kind, statement.position)
+			}
+
+			reduced, err := r.reduceGet(statement)
+			if err != nil {
+				return nil, err
+			}
+
+			workspace := statement.variable.(*IdentifierExpression).value
+			statements = append(statements, &AlgebraStatement{statement.kind, workspace, reduced})
+		default:
+			return nil, r.unsupported(expression.GetKind(), entity.Position{})
+		}
+	}
+
+	return statements, nil
+}
+
+func (r *Reducer) reduceGet(expression *GetHoldExpression) (AlgebraExpression, error) {
+	free := make([]string, 0)
+	targets := make([]string, 0, len(expression.relations))
+	for _, target := range expression.relations {
+		identifier, isIdentifier := target.(*IdentifierExpression)
+		if !isIdentifier {
+			return nil, r.unsupported(columnName(target), positionOf(target, expression.position))
+		}
+
+		variable := identifier.value
+		switch identifier.kind {
+		case model.FREE_RELATION.String():
+			targets = append(targets, variable+".*")
+		case model.ATTRIBUTE.String():
+			attr := model.Attribute{}
+			attribute, err := attr.ExtractAttribute(identifier.value, identifier.position)
+			if err != nil {
+				return nil, err
+			}
+
+			variable = attribute.Relation
+			targets = append(targets, identifier.value)
+		default:
+			return nil, r.unsupported(identifier.value, identifier.position)
+		}
+
+		if !slices.Contains(free, variable) {
+			free = append(free, variable)
+		}
+	}
+
+	used := make(map[string]bool)
+	for _, variable := range free {
+		used[variable] = true
+	}
+
+	var prefix []quantifier
+	var matrix Expression
+	if expression.expression != nil {
+		prefix, matrix = r.prenex(expression.expression, used)
+	}
+
+	var result AlgebraExpression
+	for _, variable := range free {
+		result = r.product(result, &RenameNode{variable, &RelationNode{name: r.resolve(variable)}})
+	}
+
+	for _, q := range prefix {
+		result = r.product(result, &RenameNode{q.variable, &RelationNode{name: q.relation}})
+	}
+
+	if matrix != nil {
+		result = &SelectionNode{condition: matrix, child: result}
+	}
+
+	remaining := make([]string, 0, len(free)+len(prefix))
+	for _, variable := range free {
+		remaining = append(remaining, variable+".*")
+	}
+
+	for _, q := range prefix {
+		remaining = append(remaining, q.variable+".*")
+	}
+
+	for i := len(prefix) - 1; i >= 0; i-- {
+		remaining = remaining[:len(remaining)-1]
+		if prefix[i].kind == model.EXISTS.String() {
+			result = &ProjectionNode{attributes: slices.Clone(remaining), child: result}
+		} else {
+			result = &DivisionNode{left: result, right: &RenameNode{prefix[i].variable, &RelationNode{name: prefix[i].relation}}}
+		}
+	}
+
+	return &ProjectionNode{attributes: targets, child: result}, nil
+}
+
+// prenex splits a qualification into a quantifier prefix and a matrix free of
+// quantifiers. Every quantified variable gets a name not in used, so that
+// quantifiers can be moved over the rest of the formula without capture.
+func (r *Reducer) prenex(expression Expression, used map[string]bool) ([]quantifier, Expression) {
+	switch node := expression.(type) {
+	case *BinaryExpression:
+		switch node.kind {
+		case model.EXISTS.String(), model.FOR_ALL.String():
+			variable := node.left.(*IdentifierExpression).value
+			alias := r.fresh(variable, used)
+			body := node.right
+			if alias != variable {
+				body = rename(body, variable, alias)
+			}
+
+			prefix, matrix := r.prenex(body, used)
+			return append([]quantifier{{node.kind, alias, r.resolve(variable)}}, prefix...), matrix
+		case model.CONJUNCTION.String(), model.DISJUNCTION.String():
+			leftPrefix, left := r.prenex(node.left, used)
+			rightPrefix, right := r.prenex(node.right, used)
+			return append(leftPrefix, rightPrefix...), &BinaryExpression{node.kind, left, right, node.position}
+		case model.IMPLICATION.String():
+			leftPrefix, left := r.prenex(&UnaryExpression{model.NEGATION.String(), node.left, node.position}, used)
+			rightPrefix, right := r.prenex(node.right, used)
+			return append(leftPrefix, rightPrefix...), &BinaryExpression{model.DISJUNCTION.String(), left, right, node.position}
+		}
+	case *UnaryExpression:
+		if node.kind == model.NEGATION.String() {
+			prefix, matrix := r.prenex(node.expression, used)
+			for i := range prefix {
+				prefix[i].kind = dual(prefix[i].kind)
+			}
+
+			return prefix, &UnaryExpression{node.kind, matrix, node.position}
+		}
+	}
+
+	return nil, expression
+}
+
+func (r *Reducer) product(left, right AlgebraExpression) AlgebraExpression {
+	if left == nil {
+		return right
+	}
+
+	return &ProductNode{left: left, right: right}
+}
+
+// resolve maps a range variable to the relation it ranges over.
+func (r *Reducer) resolve(variable string) string {
+	if relation, exists := r.ranges[variable]; exists {
+		return relation
+	}
+
+	return variable
+}
+
+func (r *Reducer) fresh(variable string, used map[string]bool) string {
+	alias := variable
+	for suffix := 2; used[alias]; suffix++ {
+		alias = variable + strconv.Itoa(suffix)
+	}
+
+	used[alias] = true
+	return alias
+}
+
+func (r *Reducer) unsupported(construct string, position entity.Position) error {
+	return &entity.CustomError{
+		ErrorType: entity.ResponseTypes["CE"],
+		Message:   fmt.Sprintf("%s cannot be translated into relational algebra", construct),
+		Position:  position,
+	}
+}
+
+func positionOf(expression Expression, fallback entity.Position) entity.Position {
+	switch node := expression.(type) {
+	case *FunctionExpression:
+		return node.position
+	case *BinaryExpression:
+		return node.position
+	case *UnaryExpression:
+		return node.position
+	default:
+		return fallback
+	}
+}
+
+func dual(kind string) string {
+	if kind == model.EXISTS.String() {
+		return model.FOR_ALL.String()
+	}
+
+	return model.EXISTS.String()
+}
+
+// rename returns a copy of a qualification in which the variable from is
+// called to. Subformulas that bind from again are left as they are.
+func rename(expression Expression, from, to string) Expression {
+	switch node := expression.(type) {
+	case *IdentifierExpression:
+		switch node.kind {
+		case model.ATTRIBUTE.String():
+			attr := model.Attribute{}
+			attribute, err := attr.ExtractAttribute(node.value, node.position)
+			if err != nil || attribute.Relation != from {
+				return node
+			}
+
+			return &IdentifierExpression{node.kind, to + "." + attribute.Attribute, node.position}
+		case model.FREE_RELATION.String(), model.BIND_RELATION.String():
+			if node.value == from {
+				return &IdentifierExpression{node.kind, to, node.position}
+			}
+		}
+
+		return node
+	case *BinaryExpression:
+		if (node.kind == model.EXISTS.String() || node.kind == model.FOR_ALL.String()) &&
+			node.left.(*IdentifierExpression).value == from {
+			return node
+		}
+
+		return &BinaryExpression{node.kind, rename(node.left, from, to), rename(node.right, from, to), node.position}
+	case *UnaryExpression:
+		return &UnaryExpression{node.kind, rename(node.expression, from, to), node.position}
+	case *FunctionExpression:
+		if slices.Contains(attributeRelations(node.argument), from) || node.argument.(*IdentifierExpression).value == from {
+			return node
+		}
+
+		return &FunctionExpression{node.kind, node.argument, rename(node.qualification, from, to), node.position}
+	case *InExpression:
+		values := make([]Expression, len(node.values))
+		for i, value := range node.values {
+			values[i] = rename(value, from, to)
+		}
+
+		return &InExpression{node.kind, rename(node.operand, from, to), values, node.position}
+	case *BetweenExpression:
+		return &BetweenExpression{node.kind, rename(node.operand, from, to), rename(node.lower, from, to), rename(node.upper, from, to), node.position}
+	case *PatternExpression:
+		return &PatternExpression{node.kind, rename(node.operand, from, to), node.pattern, node.matcher, node.position}
+	default:
+		return expression
+	}
+}
@@ -0,0 +1,130 @@
+package operation
+
+import (
+	"alpha-executor/entity"
+	"bufio"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// reduce translates an ALPHA program into relational algebra and executes
+// it as the algebra endpoint does. It returns the algebra of every statement
+// and the GET workspaces.
+func reduce(t *testing.T, data, query string) ([]string, entity.Workspaces, error) {
+	t.Helper()
+	program, errors := GenerateAST(bufio.NewReader(strings.NewReader(query)))
+	if len(errors) > 0 {
+		t.Fatalf("%s: %v", query, entity.CustomErrors(errors))
+	}
+
+	relations := parseRelations(t, data)
+	schemas, err := entity.NewSchemas(relations, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if errors = NewBinder(schemas).Bind(&program); len(errors) > 0 {
+		t.Fatalf("%s: %v", query, entity.CustomErrors(errors))
+	}
+
+	statements, err := NewReducer().Reduce(&program)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	alphaRepository := newRepository()
+	alphaRepository.AddRelations(relations)
+	executor := NewAlgebraExecutor(alphaRepository)
+	algebra := make([]string, 0, len(statements))
+	for _, statement := range statements {
+		if err = executor.Execute(statement); err != nil {
+			return nil, nil, err
+		}
+
+		algebra = append(algebra, statement.String())
+	}
+
+	return algebra, alphaRepository.GetGetRelations(), nil
+}
+
+func TestReduction(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{`GET W (EMP)`, []string{`W := π[EMP.*](ρ[EMP](EMP))`}},
+		{`GET W (EMP.name): EMP.salary > 95`, []string{`W := π[EMP.name](σ[EMP.salary > 95](ρ[EMP](EMP)))`}},
+		{
+			`GET W (EMP.name, DEPT.title): EMP.dept = DEPT.dept`,
+			[]string{`W := π[EMP.name, DEPT.title](σ[EMP.dept = DEPT.dept](ρ[EMP](EMP) × ρ[DEPT](DEPT)))`},
+		},
+		{
+			`RANGE EMP E; GET W (DEPT.title): ∃E (E.dept = DEPT.dept ∧ E.salary > 200)`,
+			[]string{
+				`E := EMP`,
+				`W := π[DEPT.title](π[DEPT.*](σ[(E.dept = DEPT.dept) ∧ (E.salary > 200)](ρ[DEPT](DEPT) × ρ[E](EMP))))`,
+			},
+		},
+		{
+			`RANGE EMP E; GET W (DEPT.title): ∀E (E.dept = DEPT.dept → E.salary > 95)`,
+			[]string{
+				`E := EMP`,
+				`W := π[DEPT.title](σ[¬(E.dept = DEPT.dept) ∨ (E.salary > 95)](ρ[DEPT](DEPT) × ρ[E](EMP)) ÷ ρ[E](EMP))`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		algebra, _, err := reduce(t, employees, test.query)
+		if err != nil {
+			t.Fatalf("%s: %v", test.query, err)
+		}
+
+		if !slices.Equal(algebra, test.expected) {
+			t.Errorf("%s: got %q, want %q", test.query, algebra, test.expected)
+		}
+	}
+}
+
+func TestReductionAgreesWithInterpreter(t *testing.T) {
+	queries := []string{
+		`GET W (EMP.name): EMP.salary > 95`,
+		`GET W (EMP.name, DEPT.title): EMP.dept = DEPT.dept`,
+		`RANGE EMP E; GET W (DEPT.title): ∃E (E.dept = DEPT.dept ∧ E.salary > 200)`,
+		`RANGE EMP E; GET W (DEPT.title): ∀E (E.dept = DEPT.dept → E.salary > 95)`,
+		`RANGE EMP E; GET W (DEPT.title): ¬∃E (E.dept = DEPT.dept ∧ E.salary < 95)`,
+		`RANGE DEPT D; GET W (EMP.name): ∃D (D.dept = EMP.dept ∧ D.title = "sales")`,
+	}
+
+	for _, query := range queries {
+		_, reduced, err := reduce(t, employees, query)
+		if err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+
+		interpreted, _, err := run(t, employees, query)
+		if err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+
+		if !reduced["W"].Relation.RelationEqual(interpreted["W"].Relation) {
+			t.Errorf("%s: got %v, the interpreter %v", query, reduced["W"].Relation, interpreted["W"].Relation)
+		}
+	}
+}
+
+func TestReductionRejectsUntranslatableStatements(t *testing.T) {
+	tests := []struct {
+		query   string
+		message string
+	}{
+		{`GET W (COUNT(EMP))`, "COUNT(EMP) cannot be translated into relational algebra at 1 line, 8 column"},
+		{`HOLD W (EMP.name)`, "HOLD cannot be translated into relational algebra at 1 line, 1 column"},
+	}
+
+	for _, test := range tests {
+		_, _, err := reduce(t, employees, test.query)
+		expectError(t, err, test.message)
+	}
+}
@@ -0,0 +1,103 @@
+package operation
+
+import (
+	"alpha-executor/entity"
+	"alpha-executor/model"
+	"maps"
+)
+
+// scope tracks the relations, range variables and workspaces a program has
+// declared so far, with their schemas. It is shared by the static passes that
+// run before interpretation.
+type scope struct {
+	schemas entity.Schemas
+	ranges  map[string]string
+}
+
+func newScope(schemas entity.Schemas) *scope {
+	return &scope{
+		schemas: maps.Clone(schemas),
+		ranges:  make(map[string]string),
+	}
+}
+
+func (s *scope) declareRange(variable, relation string) {
+	s.ranges[variable] = relation
+}
+
+func (s *scope) declareWorkspace(name string, schema *entity.Schema) {
+	s.schemas[name] = schema
+}
+
+// resolve maps a range variable to the relation it ranges over.
+func (s *scope) resolve(relation string) string {
+	if source, exists := s.ranges[relation]; exists {
+		return source
+	}
+
+	return relation
+}
+
+// isRange reports whether name is a declared range variable.
+func (s *scope) isRange(name string) bool {
+	_, exists := s.ranges[name]
+	return exists
+}
+
+// schema returns the schema of a relation, range variable or workspace.
+func (s *scope) schema(name string) (*entity.Schema, bool) {
+	schema, exists := s.schemas[s.resolve(name)]
+	return schema, exists
+}
+
+// attribute looks up a `Relation.attribute` reference.
+func (s *scope) attribute(identifier *IdentifierExpression) (entity.SchemaAttribute, bool) {
+	attr := model.Attribute{}
+	attribute, err := attr.ExtractAttribute(identifier.value, identifier.position)
+	if err != nil {
+		return entity.SchemaAttribute{}, false
+	}
+
+	schema, exists := s.schema(attribute.Relation)
+	if !exists {
+		return entity.SchemaAttribute{}, false
+	}
+
+	return schema.Attribute(attribute.Attribute)
+}
+
+// workspaceSchema derives the schema of a GET workspace from its target list.
+// typeOf gives the type of computed columns.
+func (s *scope) workspaceSchema(targets []Expression, typeOf func(Expression) entity.AttributeType) *entity.Schema {
+	schema := make(entity.Schema, 0, len(targets))
+	for _, target := range targets {
+		identifier, isIdentifier := target.(*IdentifierExpression)
+		switch {
+		case isIdentifier && identifier.kind == model.FREE_RELATION.String():
+			if relationSchema, exists := s.schema(identifier.value); exists {
+				schema = append(schema, *relationSchema...)
+			}
+		case isIdentifier && identifier.kind == model.ATTRIBUTE.String():
+			attr := model.Attribute{}
+			attribute, err := attr.ExtractAttribute(identifier.value, identifier.position)
+			if err != nil {
+				continue
+			}
+
+			schemaAttribute, _ := s.attribute(identifier)
+			schema = append(schema, entity.SchemaAttribute{
+				Name:     attribute.Attribute,
+				Type:     schemaAttribute.Type,
+				Nullable: true,
+			})
+		default:
+			schema = append(schema, entity.SchemaAttribute{
+				Name:     columnName(target),
+				Type:     typeOf(target),
+				Nullable: true,
+			})
+		}
+	}
+
+	return &schema
+}
@@ -0,0 +1,754 @@
+package operation
+
+import (
+	"alpha-executor/entity"
+	"alpha-executor/model"
+	"bufio"
+	"fmt"
+	"slices"
+)
+
+// SQLCompiler compiles SQL queries into ALPHA programs, which the binder,
+// the type checker and the interpreter then treat as any other:
+//
+//   - every table of a FROM clause becomes a range variable named after
+//     the table, unless that name is taken;
+//   - the tables the select list refers to are the free variables of a GET
+//     and the other ones are quantified existentially over the WHERE
+//     condition or, without one, required to have tuples;
+//   - EXISTS and IN subqueries become existential quantifiers and scalar
+//     subqueries of a single aggregate become ALPHA aggregate functions;
+//   - GROUP BY, of a single table, gives every aggregate of the select list
+//     the condition that its tuples belong to the group of the selected one;
+//   - ORDER BY and LIMIT become the sort and the row limit of the GET.
+//
+// The result of every query is a workspace, named by CREATE VIEW or else
+// W1, W2, … in order. As the workspaces are relations, duplicates are
+// dropped as by SELECT DISTINCT. ALPHA cannot rename the targets of a GET,
+// so AS is rejected in the select list and the columns are named after the
+// targets: a column after its attribute, COUNT(*) over a table T after
+// COUNT(T), SUM(T.a) after TOTAL(T.a) and other aggregates alike.
+type SQLCompiler struct {
+	schemas entity.Schemas
+	ranges  map[string]string
+	body    []Expression
+	unnamed int
+}
+
+// sqlBinding is a table of a FROM clause bound to the range variable its
+// name stands for.
+type sqlBinding struct {
+	name     string
+	variable string
+	relation string
+}
+
+type sqlScope struct {
+	bindings []sqlBinding
+	outer    *sqlScope
+}
+
+func NewSQLCompiler(schemas entity.Schemas) *SQLCompiler {
+	// The schemas of the views are the compiler's own.
+	own := make(entity.Schemas, len(schemas))
+	for name, schema := range schemas {
+		own[name] = schema
+	}
+
+	return &SQLCompiler{
+		schemas: own,
+		ranges:  make(map[string]string),
+		body:    make([]Expression, 0),
+	}
+}
+
+func GenerateSQLProgram(reader *bufio.Reader, schemas entity.Schemas) (Program, []*entity.CustomError) {
+	lexer := model.NewSQLLexer(reader)
+	output := lexer.Lex()
+	errors := lexer.Errors()
+	compiler := NewSQLCompiler(schemas)
+	for _, statement := range output {
+		if len(statement) > 0 && !hasIllegalToken(statement) {
+			parser := NewSQLParser(statement)
+			query, err := parser.ParseStatement()
+			if err == nil {
+				err = compiler.Compile(query)
+			}
+
+			if err != nil {
+				errors = append(errors, err.(*entity.CustomError))
+			}
+		}
+	}
+
+	return compiler.Program(), errors
+}
+
+func (c *SQLCompiler) Program() Program {
+	return Program{model.PROGRAM.String(), c.body}
+}
+
+// Compile appends the RANGE statements and the GET of a query to the program.
+func (c *SQLCompiler) Compile(query *sqlQuery) error {
+	workspace := query.view
+	if _, exists := c.schemas[workspace]; exists {
+		return c.error(query.position, "relation %s already exists", workspace)
+	}
+
+	if workspace == "" {
+		c.unnamed++
+		workspace = fmt.Sprintf("W%d", c.unnamed)
+	}
+
+	get := &GetHoldExpression{
+		kind:     model.GET.String(),
+		variable: &IdentifierExpression{model.FREE_RELATION.String(), workspace, query.position},
+		rows:     query.limit,
+		sort:     make([]Expression, 0),
+		position: query.position,
+	}
+
+	var scope *sqlScope
+	var err error
+	switch {
+	case len(query.group) > 0:
+		scope, err = c.compileGrouped(query, get)
+	case slices.ContainsFunc(query.items, containsAggregate):
+		err = c.compileAggregated(query, get)
+	default:
+		scope, err = c.compileSelect(query, get)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, key := range query.order {
+		direction := key.(*UnaryExpression)
+		column, err := c.column(direction.expression.(*IdentifierExpression), scope)
+		if err != nil {
+			return err
+		}
+
+		get.sort = append(get.sort, &UnaryExpression{direction.kind, column, direction.position})
+	}
+
+	c.body = append(c.body, get)
+	c.schemas[workspace] = c.workspaceSchema(get.relations)
+	return nil
+}
+
+func (c *SQLCompiler) compileSelect(query *sqlQuery, get *GetHoldExpression) (*sqlScope, error) {
+	scope, err := c.bindTables(query.from, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if query.items == nil {
+		get.relations, err = c.expandStar(scope)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, item := range query.items {
+		if identifier, isIdentifier := item.(*IdentifierExpression); isIdentifier && identifier.kind == model.ATTRIBUTE.String() {
+			if table, isStar := cutStar(identifier.value); isStar {
+				binding, err := c.table(table, identifier.position, scope)
+				if err != nil {
+					return nil, err
+				}
+
+				get.relations = append(get.relations, &IdentifierExpression{model.FREE_RELATION.String(), binding.variable, identifier.position})
+				continue
+			}
+		}
+
+		target, err := c.condition(item, scope)
+		if err != nil {
+			return nil, err
+		}
+
+		get.relations = append(get.relations, target)
+	}
+
+	var condition Expression
+	if query.where != nil {
+		if condition, err = c.condition(query.where, scope); err != nil {
+			return nil, err
+		}
+	}
+
+	free := make([]string, 0)
+	for _, target := range get.relations {
+		free = append(free, freeVariables(target, nil)...)
+	}
+
+	quantified := make([]sqlBinding, 0)
+	for _, binding := range scope.bindings {
+		if !slices.Contains(free, binding.variable) {
+			quantified = append(quantified, binding)
+		}
+	}
+
+	switch {
+	case condition != nil:
+		get.expression = c.quantify(quantified, condition, query.position)
+	case len(quantified) > 0:
+		// Without a condition, the product of the tables has tuples if the
+		// ones that are not selected have.
+		get.expression = c.nonEmpty(quantified, query.position)
+	}
+
+	return scope, nil
+}
+
+// compileAggregated compiles a query of aggregates only, without GROUP BY;
+// its WHERE condition becomes the qualification of every aggregate.
+func (c *SQLCompiler) compileAggregated(query *sqlQuery, get *GetHoldExpression) error {
+	if len(query.from) != 1 {
+		return c.error(query.position, "aggregates are supported for a single table")
+	}
+
+	scope, err := c.bindTables(query.from, nil)
+	if err != nil {
+		return err
+	}
+
+	var qualification Expression
+	if query.where != nil {
+		if qualification, err = c.condition(query.where, scope); err != nil {
+			return err
+		}
+	}
+
+	for _, item := range query.items {
+		target, err := c.grouped(item, scope, nil, func(function *FunctionExpression) (Expression, error) {
+			return c.aggregate(function, scope, qualification)
+		})
+		if err != nil {
+			return err
+		}
+
+		get.relations = append(get.relations, target)
+	}
+
+	return nil
+}
+
+// compileGrouped compiles a GROUP BY query: the selected tuples range over
+// the table by a variable of their own, while the aggregates range over it
+// by the variable named after the table, restricted to the group.
+func (c *SQLCompiler) compileGrouped(query *sqlQuery, get *GetHoldExpression) (*sqlScope, error) {
+	if len(query.from) != 1 {
+		return nil, c.error(query.position, "GROUP BY is supported for a single table")
+	}
+
+	if query.items == nil {
+		return nil, c.error(query.position, "SELECT * cannot be grouped")
+	}
+
+	group, err := c.bindTables(query.from, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	scope, err := c.bindTables(query.from, group)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]Expression, 0, len(query.group))
+	var qualification Expression
+	for _, key := range query.group {
+		column, err := c.column(key.(*IdentifierExpression), scope)
+		if err != nil {
+			return nil, err
+		}
+
+		member, err := c.column(key.(*IdentifierExpression), &sqlScope{group.bindings, nil})
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, column)
+		qualification = conjoin(qualification, c.sameGroup(member, column))
+	}
+
+	if query.where != nil {
+		where, err := c.condition(query.where, &sqlScope{group.bindings, nil})
+		if err != nil {
+			return nil, err
+		}
+
+		qualification = conjoin(qualification, where)
+		if get.expression, err = c.condition(query.where, scope); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, item := range query.items {
+		target, err := c.grouped(item, scope, keys, func(function *FunctionExpression) (Expression, error) {
+			return c.aggregate(function, &sqlScope{group.bindings, nil}, qualification)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		get.relations = append(get.relations, target)
+	}
+
+	for i, key := range keys {
+		if !slices.ContainsFunc(get.relations, func(target Expression) bool { return sameColumn(target, key) }) {
+			column := query.group[i].(*IdentifierExpression)
+			return nil, c.error(column.position, "grouped column %s is not selected", column.value)
+		}
+	}
+
+	return scope, nil
+}
+
+// grouped compiles an item of a query with aggregates: columns outside the
+// aggregates have to be among keys.
+func (c *SQLCompiler) grouped(item Expression, scope *sqlScope, keys []Expression, aggregate func(*FunctionExpression) (Expression, error)) (Expression, error) {
+	switch node := item.(type) {
+	case *FunctionExpression:
+		return aggregate(node)
+	case *IdentifierExpression:
+		if node.kind != model.ATTRIBUTE.String() && node.kind != model.FREE_RELATION.String() {
+			return node, nil
+		}
+
+		column, err := c.column(node, scope)
+		if err != nil {
+			return nil, err
+		}
+
+		if !slices.ContainsFunc(keys, func(key Expression) bool { return sameColumn(key, column) }) {
+			return nil, c.error(node.position, "column %s is neither grouped nor aggregated", node.value)
+		}
+
+		return column, nil
+	case *BinaryExpression:
+		left, err := c.grouped(node.left, scope, keys, aggregate)
+		if err != nil {
+			return nil, err
+		}
+
+		right, err := c.grouped(node.right, scope, keys, aggregate)
+		if err != nil {
+			return nil, err
+		}
+
+		return &BinaryExpression{node.kind, left, right, node.position}, nil
+	case *UnaryExpression:
+		expression, err := c.grouped(node.expression, scope, keys, aggregate)
+		if err != nil {
+			return nil, err
+		}
+
+		return &UnaryExpression{node.kind, expression, node.position}, nil
+	default:
+		return nil, c.error(entity.Position{}, "%s cannot be selected with aggregates", item.GetKind())
+	}
+}
+
+// aggregate gives an aggregate function the variable of its table and the
+// qualification its tuples have to satisfy; COUNT(*) counts the tuples.
+func (c *SQLCompiler) aggregate(function *FunctionExpression, scope *sqlScope, qualification Expression) (Expression, error) {
+	var argument Expression = &IdentifierExpression{model.FREE_RELATION.String(), scope.bindings[0].variable, function.position}
+	if function.argument != nil {
+		column, err := c.column(function.argument.(*IdentifierExpression), scope)
+		if err != nil {
+			return nil, err
+		}
+
+		argument = column
+	}
+
+	return &FunctionExpression{function.kind, argument, qualification, function.position}, nil
+}
+
+// sameGroup is the condition that two tuples have the same value of a
+// grouped column; GROUP BY puts NULLs in one group.
+func (c *SQLCompiler) sameGroup(member, selected *IdentifierExpression) Expression {
+	var equal Expression = &BinaryExpression{model.EQUALS.String(), member, selected, member.position}
+	if !c.nullable(member) {
+		return equal
+	}
+
+	bothNull := &BinaryExpression{
+		model.CONJUNCTION.String(),
+		&UnaryExpression{model.IS_NULL.String(), member, member.position},
+		&UnaryExpression{model.IS_NULL.String(), selected, member.position},
+		member.position,
+	}
+
+	return &BinaryExpression{model.DISJUNCTION.String(), equal, bothNull, member.position}
+}
+
+func (c *SQLCompiler) nullable(column *IdentifierExpression) bool {
+	attr := model.Attribute{}
+	attribute, err := attr.ExtractAttribute(column.value, column.position)
+	if err != nil {
+		return true
+	}
+
+	schema, exists := c.schemas[c.relation(attribute.Relation)]
+	if !exists {
+		return true
+	}
+
+	schemaAttribute, exists := schema.Attribute(attribute.Attribute)
+	return !exists || schemaAttribute.Nullable
+}
+
+// condition resolves the columns of an SQL expression and compiles its
+// subqueries.
+func (c *SQLCompiler) condition(expression Expression, scope *sqlScope) (Expression, error) {
+	var err error
+	switch node := expression.(type) {
+	case *IdentifierExpression:
+		if node.kind != model.ATTRIBUTE.String() && node.kind != model.FREE_RELATION.String() {
+			return node, nil
+		}
+
+		return c.column(node, scope)
+	case *BinaryExpression:
+		compiled := *node
+		if compiled.left, err = c.condition(node.left, scope); err != nil {
+			return nil, err
+		}
+
+		compiled.right, err = c.condition(node.right, scope)
+		return &compiled, err
+	case *UnaryExpression:
+		compiled := *node
+		compiled.expression, err = c.condition(node.expression, scope)
+		return &compiled, err
+	case *InExpression:
+		compiled := *node
+		compiled.values = make([]Expression, len(node.values))
+		for i, value := range node.values {
+			if compiled.values[i], err = c.condition(value, scope); err != nil {
+				return nil, err
+			}
+		}
+
+		compiled.operand, err = c.condition(node.operand, scope)
+		return &compiled, err
+	case *BetweenExpression:
+		compiled := *node
+		if compiled.lower, err = c.condition(node.lower, scope); err != nil {
+			return nil, err
+		}
+
+		if compiled.upper, err = c.condition(node.upper, scope); err != nil {
+			return nil, err
+		}
+
+		compiled.operand, err = c.condition(node.operand, scope)
+		return &compiled, err
+	case *PatternExpression:
+		compiled := *node
+		compiled.operand, err = c.condition(node.operand, scope)
+		return &compiled, err
+	case *FunctionExpression:
+		return nil, c.error(node.position, "aggregate %s is not allowed here", node.kind)
+	case *SubqueryExpression:
+		return c.subquery(node, scope)
+	default:
+		return expression, nil
+	}
+}
+
+func (c *SQLCompiler) subquery(subquery *SubqueryExpression, scope *sqlScope) (Expression, error) {
+	query := subquery.query
+	if len(query.group) > 0 || len(query.order) > 0 || query.limit != nil {
+		return nil, c.error(query.position, "GROUP BY, ORDER BY and LIMIT are not supported in subqueries")
+	}
+
+	inner, err := c.bindTables(query.from, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	var where Expression
+	if query.where != nil {
+		if where, err = c.condition(query.where, inner); err != nil {
+			return nil, err
+		}
+	}
+
+	switch subquery.kind {
+	case model.EXISTS.String():
+		if where != nil {
+			return c.quantify(inner.bindings, where, subquery.position), nil
+		}
+
+		// Without a condition, the subquery has rows if its tables have.
+		return c.nonEmpty(inner.bindings, subquery.position), nil
+	case model.IN.String():
+		if len(query.items) != 1 || containsAggregate(query.items[0]) {
+			return nil, c.error(query.position, "the subquery of IN has to select one column")
+		}
+
+		operand, err := c.condition(subquery.operand, scope)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := c.condition(query.items[0], inner)
+		if err != nil {
+			return nil, err
+		}
+
+		equal := &BinaryExpression{model.EQUALS.String(), operand, value, subquery.position}
+		return c.quantify(inner.bindings, conjoin(equal, where), subquery.position), nil
+	default:
+		function, isFunction := Expression(nil), false
+		if len(query.items) == 1 {
+			function, isFunction = query.items[0].(*FunctionExpression)
+		}
+
+		if !isFunction || len(query.from) != 1 {
+			return nil, c.error(query.position, "a scalar subquery has to select one aggregate of one table")
+		}
+
+		return c.aggregate(function.(*FunctionExpression), inner, where)
+	}
+}
+
+// column resolves a column reference to the attribute of its range variable:
+// t.a names the table, while a is the attribute of the only table of the
+// innermost scope having it.
+func (c *SQLCompiler) column(identifier *IdentifierExpression, scope *sqlScope) (*IdentifierExpression, error) {
+	if identifier.kind == model.ATTRIBUTE.String() {
+		attr := model.Attribute{}
+		attribute, err := attr.ExtractAttribute(identifier.value, identifier.position)
+		if err != nil {
+			return nil, err
+		}
+
+		if attribute.Attribute == "*" {
+			return nil, c.error(identifier.position, "%s is only allowed in the select list", identifier.value)
+		}
+
+		binding, err := c.table(attribute.Relation, identifier.position, scope)
+		if err != nil {
+			return nil, err
+		}
+
+		return &IdentifierExpression{model.ATTRIBUTE.String(), binding.variable + "." + attribute.Attribute, identifier.position}, nil
+	}
+
+	for ; scope != nil; scope = scope.outer {
+		found := make([]sqlBinding, 0, 1)
+		for _, binding := range scope.bindings {
+			if _, exists := c.schemas[binding.relation].Attribute(identifier.value); exists {
+				found = append(found, binding)
+			}
+		}
+
+		if len(found) > 1 {
+			return nil, c.error(identifier.position, "column %s is ambiguous: %s or %s", identifier.value, found[0].name, found[1].name)
+		}
+
+		if len(found) == 1 {
+			return &IdentifierExpression{model.ATTRIBUTE.String(), found[0].variable + "." + identifier.value, identifier.position}, nil
+		}
+	}
+
+	return nil, c.error(identifier.position, "unknown column %s", identifier.value)
+}
+
+func (c *SQLCompiler) table(name string, position entity.Position, scope *sqlScope) (sqlBinding, error) {
+	for ; scope != nil; scope = scope.outer {
+		for _, binding := range scope.bindings {
+			if binding.name == name {
+				return binding, nil
+			}
+		}
+	}
+
+	return sqlBinding{}, c.error(position, "unknown table %s", name)
+}
+
+// bindTables binds the tables of a FROM clause in a scope inside outer,
+// declaring the range variables that are needed.
+func (c *SQLCompiler) bindTables(tables []sqlTable, outer *sqlScope) (*sqlScope, error) {
+	scope := &sqlScope{outer: outer}
+	for _, table := range tables {
+		if _, exists := c.schemas[table.relation]; !exists {
+			return nil, c.error(table.position, "unknown relation %s", table.relation)
+		}
+
+		if slices.ContainsFunc(scope.bindings, func(binding sqlBinding) bool { return binding.name == table.name }) {
+			return nil, c.error(table.position, "table name %s is used twice", table.name)
+		}
+
+		variable := table.name
+		for i := 1; !c.available(variable, table.relation, scope); i++ {
+			variable = fmt.Sprintf("%s%d", table.name, i)
+		}
+
+		if _, declared := c.ranges[variable]; variable != table.relation && !declared {
+			c.ranges[variable] = table.relation
+			c.body = append(c.body, &RangeExpression{
+				kind:     model.RANGE.String(),
+				relation: &IdentifierExpression{model.FREE_RELATION.String(), table.relation, table.position},
+				variable: &IdentifierExpression{model.FREE_RELATION.String(), variable, table.position},
+				position: table.position,
+			})
+		}
+
+		scope.bindings = append(scope.bindings, sqlBinding{table.name, variable, table.relation})
+	}
+
+	return scope, nil
+}
+
+// available reports whether a variable may range over relation in a scope:
+// it is not bound there, and it is the relation itself, a variable already
+// ranging over it or a new name.
+func (c *SQLCompiler) available(variable, relation string, scope *sqlScope) bool {
+	for ; scope != nil; scope = scope.outer {
+		for _, binding := range scope.bindings {
+			if binding.variable == variable {
+				return false
+			}
+		}
+	}
+
+	if _, isRelation := c.schemas[variable]; isRelation {
+		return variable == relation
+	}
+
+	declared, isDeclared := c.ranges[variable]
+	return !isDeclared || declared == relation
+}
+
+// relation returns the relation a variable ranges over.
+func (c *SQLCompiler) relation(variable string) string {
+	if relation, isDeclared := c.ranges[variable]; isDeclared {
+		return relation
+	}
+
+	return variable
+}
+
+func (c *SQLCompiler) quantify(bindings []sqlBinding, body Expression, position entity.Position) Expression {
+	for i := len(bindings) - 1; i >= 0; i-- {
+		variable := &IdentifierExpression{model.BIND_RELATION.String(), bindings[i].variable, position}
+		body = &BinaryExpression{model.EXISTS.String(), variable, body, position}
+	}
+
+	return body
+}
+
+// nonEmpty is the condition that the relations of bindings have tuples.
+func (c *SQLCompiler) nonEmpty(bindings []sqlBinding, position entity.Position) Expression {
+	var nonEmpty Expression
+	for _, binding := range bindings {
+		count := &FunctionExpression{
+			kind:     model.COUNT.String(),
+			argument: &IdentifierExpression{model.FREE_RELATION.String(), binding.variable, position},
+			position: position,
+		}
+
+		zero := &IdentifierExpression{model.INTEGER.String(), "0", position}
+		nonEmpty = conjoin(nonEmpty, &BinaryExpression{model.GREATER_THAN.String(), count, zero, position})
+	}
+
+	return nonEmpty
+}
+
+// expandStar selects all attributes of the tables of a scope: the whole
+// tuples of a single one, or their attributes one by one.
+func (c *SQLCompiler) expandStar(scope *sqlScope) ([]Expression, error) {
+	targets := make([]Expression, 0)
+	for _, binding := range scope.bindings {
+		if len(scope.bindings) == 1 {
+			return append(targets, &IdentifierExpression{model.FREE_RELATION.String(), binding.variable, entity.Position{}}), nil
+		}
+
+		for _, attribute := range *c.schemas[binding.relation] {
+			targets = append(targets, &IdentifierExpression{model.ATTRIBUTE.String(), binding.variable + "." + attribute.Name, entity.Position{}})
+		}
+	}
+
+	return targets, nil
+}
+
+// workspaceSchema is the schema of the workspace of a GET, so that later
+// queries can select from it.
+func (c *SQLCompiler) workspaceSchema(targets []Expression) *entity.Schema {
+	schema := make(entity.Schema, 0)
+	for _, target := range targets {
+		identifier, isIdentifier := target.(*IdentifierExpression)
+		switch {
+		case isIdentifier && identifier.kind == model.FREE_RELATION.String():
+			schema = append(schema, *c.schemas[c.relation(identifier.value)]...)
+		case isIdentifier && identifier.kind == model.ATTRIBUTE.String():
+			attr := model.Attribute{}
+			attribute, _ := attr.ExtractAttribute(identifier.value, identifier.position)
+			schemaAttribute, exists := c.schemas[c.relation(attribute.Relation)].Attribute(attribute.Attribute)
+			if !exists {
+				schemaAttribute = entity.SchemaAttribute{Name: attribute.Attribute, Nullable: true}
+			}
+
+			schema = append(schema, schemaAttribute)
+		default:
+			schema = append(schema, entity.SchemaAttribute{Name: columnName(target), Nullable: true})
+		}
+	}
+
+	return &schema
+}
+
+func (c *SQLCompiler) error(position entity.Position, format string, args ...any) *entity.CustomError {
+	return &entity.CustomError{
+		ErrorType: entity.ResponseTypes["CE"],
+		Message:   fmt.Sprintf(format, args...),
+		Position:  position,
+	}
+}
+
+func containsAggregate(expression Expression) bool {
+	switch node := expression.(type) {
+	case *FunctionExpression:
+		return true
+	case *BinaryExpression:
+		return containsAggregate(node.left) || containsAggregate(node.right)
+	case *UnaryExpression:
+		return containsAggregate(node.expression)
+	default:
+		return false
+	}
+}
+
+func sameColumn(a, b Expression) bool {
+	identifier1, isIdentifier1 := a.(*IdentifierExpression)
+	identifier2, isIdentifier2 := b.(*IdentifierExpression)
+	return isIdentifier1 && isIdentifier2 && identifier1.kind == identifier2.kind && identifier1.value == identifier2.value
+}
+
+func cutStar(value string) (string, bool) {
+	if len(value) > 2 && value[len(value)-2:] == ".*" {
+		return value[:len(value)-2], true
+	}
+
+	return value, false
+}
+
+func conjoin(left, right Expression) Expression {
+	if left == nil {
+		return right
+	}
+
+	if right == nil {
+		return left
+	}
+
+	return &BinaryExpression{model.CONJUNCTION.String(), left, right, entity.Position{}}
+}
@@ -0,0 +1,111 @@
+package operation
+
+import (
+	"alpha-executor/entity"
+	"bufio"
+	"strings"
+	"testing"
+)
+
+// sql compiles SQL queries over the relations in data and evaluates the
+// ALPHA program they become.
+func sql(t *testing.T, data string, source string) (entity.Workspaces, error) {
+	t.Helper()
+	schemas, err := entity.NewSchemas(parseRelations(t, data), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	program, errors := GenerateSQLProgram(bufio.NewReader(strings.NewReader(source)), schemas)
+	if len(errors) > 0 {
+		return nil, entity.CustomErrors(errors)
+	}
+
+	workspaces, _, err := evaluate(t, data, program)
+	return workspaces, err
+}
+
+func TestCompileSQL(t *testing.T) {
+	tests := []struct {
+		source   string
+		expected string
+	}{
+		{"SELECT E.name FROM EMP E WHERE 95 < E.salary", `[{"name": ["ann"]}, {"name": ["bob"]}]`},
+		{"SELECT id FROM EMP WHERE salary BETWEEN 95 AND 260 AND name LIKE 'b%'", `[{"id": ["2"]}]`},
+		{"SELECT E.name, D.title FROM EMP E, DEPT D WHERE E.dept = D.dept AND D.title <> 'sales'", `[{"name": ["cid"], "title": ["it"]}, {"name": ["ann"], "title": ["ops"]}]`},
+		{"SELECT E.name FROM EMP E, DEPT D", `[{"name": ["ann"]}, {"name": ["bob"]}, {"name": ["cid"]}]`},
+		{"SELECT title FROM DEPT D WHERE EXISTS (SELECT * FROM EMP E WHERE E.dept = D.dept AND E.salary > 200)", `[{"title": ["sales"]}, {"title": ["ops"]}]`},
+		{"SELECT title FROM DEPT D WHERE NOT EXISTS (SELECT * FROM EMP E WHERE E.dept = D.dept AND E.salary > 200)", `[{"title": ["it"]}]`},
+		{"SELECT title FROM DEPT WHERE dept IN (SELECT dept FROM EMP WHERE salary < 100)", `[{"title": ["it"]}]`},
+		{"SELECT name FROM EMP WHERE salary > (SELECT AVG(salary) FROM EMP)", `[{"name": ["bob"]}, {"name": ["ann"]}]`},
+		{"SELECT dept, COUNT(*) FROM EMP GROUP BY dept", `[{"dept": ["d1"], "COUNT(EMP)": ["2"]}, {"dept": ["d2"], "COUNT(EMP)": ["1"]}, {"dept": ["d3"], "COUNT(EMP)": ["1"]}]`},
+		{"SELECT COUNT(*), SUM(E.salary) FROM EMP E WHERE E.dept = 'd1'", `[{"COUNT(E)": ["2"], "TOTAL(E.salary)": ["350"]}]`},
+		{"CREATE VIEW RICH AS SELECT id, dept FROM EMP WHERE salary > 200; SELECT title FROM RICH R, DEPT D WHERE R.dept = D.dept", `[{"title": ["sales"]}, {"title": ["ops"]}]`},
+	}
+
+	for _, test := range tests {
+		workspaces, err := sql(t, employees, test.source)
+		if err != nil {
+			t.Fatalf("%s: %v", test.source, err)
+		}
+
+		expectRows(t, workspaces["W1"].Relation, test.expected)
+	}
+}
+
+func TestCompileSQLWithEmptyTable(t *testing.T) {
+	data := `{"EMP": [{"id": ["1"], "name": ["ann"]}], "DEPT": [], "PROJ": [{"code": ["p1"]}]}`
+	tests := []struct {
+		source   string
+		expected string
+	}{
+		{"SELECT E.name FROM EMP E, DEPT D", `[]`},
+		{"SELECT E.name FROM EMP E, PROJ P", `[{"name": ["ann"]}]`},
+		{"SELECT E.name FROM EMP E, PROJ P, DEPT D", `[]`},
+		{"SELECT name FROM EMP WHERE EXISTS (SELECT * FROM DEPT)", `[]`},
+		{"SELECT name FROM EMP WHERE NOT EXISTS (SELECT * FROM DEPT)", `[{"name": ["ann"]}]`},
+	}
+
+	for _, test := range tests {
+		workspaces, err := sql(t, data, test.source)
+		if err != nil {
+			t.Fatalf("%s: %v", test.source, err)
+		}
+
+		expectRows(t, workspaces["W1"].Relation, test.expected)
+	}
+}
+
+func TestCompileSQLOrderAndLimit(t *testing.T) {
+	workspaces, err := sql(t, employees, "SELECT id, salary FROM EMP ORDER BY salary DESC LIMIT 2; SELECT id FROM EMP ORDER BY id")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectOrder(t, workspaces["W1"], `[{"id": ["4"], "salary": ["300"]}, {"id": ["2"], "salary": ["250"]}]`)
+	expectOrder(t, workspaces["W2"], `[{"id": ["1"]}, {"id": ["2"]}, {"id": ["3"]}, {"id": ["4"]}]`)
+}
+
+func TestCompileSQLErrors(t *testing.T) {
+	tests := []struct {
+		source  string
+		message string
+	}{
+		{"SELECT name FROM NOPE", "unknown relation NOPE"},
+		{"SELECT grade FROM EMP", "unknown column grade"},
+		{"SELECT dept FROM EMP, DEPT", "column dept is ambiguous"},
+		{"SELECT X.name FROM EMP E", "unknown table X"},
+		{"SELECT E.name FROM EMP E, DEPT E", "table name E is used twice"},
+		{"SELECT name, COUNT(*) FROM EMP GROUP BY dept", "column name is neither grouped nor aggregated"},
+		{"SELECT COUNT(*) FROM EMP GROUP BY dept", "grouped column dept is not selected"},
+		{"SELECT COUNT(*) AS n FROM EMP", "column aliases are not supported"},
+		{"SELECT name FROM EMP WHERE COUNT(*) > 1", "is not allowed here"},
+		{"SELECT name FROM EMP WHERE dept IN (SELECT dept, title FROM DEPT)", "the subquery of IN has to select one column"},
+		{"CREATE VIEW EMP AS SELECT id FROM DEPT", "relation EMP already exists"},
+	}
+
+	for _, test := range tests {
+		_, err := sql(t, employees, test.source)
+		expectError(t, err, test.message)
+	}
+}
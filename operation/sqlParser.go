@@ -0,0 +1,547 @@
+package operation
+
+import (
+	"alpha-executor/entity"
+	"alpha-executor/model"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// sqlQuery is a SELECT statement whose names are not resolved yet; items is
+// nil for SELECT *.
+type sqlQuery struct {
+	view     string
+	items    []Expression
+	from     []sqlTable
+	where    Expression
+	group    []Expression
+	order    []Expression
+	limit    Expression
+	position entity.Position
+}
+
+// sqlTable is a relation of a FROM clause and the name it goes by.
+type sqlTable struct {
+	relation string
+	name     string
+	position entity.Position
+}
+
+// SubqueryExpression is an EXISTS, IN or scalar subquery in a condition; the
+// compiler replaces it by a quantifier or an aggregate function.
+type SubqueryExpression struct {
+	kind     string
+	operand  Expression
+	query    *sqlQuery
+	position entity.Position
+}
+
+func (s *SubqueryExpression) GetKind() string {
+	return s.kind
+}
+
+// SQLParser parses the SELECT statements of the SQL subset, optionally
+// named by CREATE VIEW, into expressions of the ALPHA syntax tree.
+type SQLParser struct {
+	parser *Parser
+}
+
+func NewSQLParser(tokens []*model.Token) *SQLParser {
+	return &SQLParser{
+		parser: NewParser(tokens),
+	}
+}
+
+func (s *SQLParser) ParseStatement() (*sqlQuery, error) {
+	view := ""
+	if s.parser.peek().Type == model.CREATE {
+		s.parser.next()
+		if _, err := s.parser.expect(model.VIEW); err != nil {
+			return nil, err
+		}
+
+		name, err := s.parser.expect(model.FREE_RELATION)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err = s.parser.expect(model.AS); err != nil {
+			return nil, err
+		}
+
+		view = name.Value
+	}
+
+	query, err := s.parseQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	if next := s.parser.peek(); next.Type != model.EOF {
+		return nil, s.parser.error(next, "unexpected %s", s.parser.describe(next))
+	}
+
+	query.view = view
+	return query, nil
+}
+
+func (s *SQLParser) parseQuery() (*sqlQuery, error) {
+	selectToken, err := s.parser.expect(model.SELECT)
+	if err != nil {
+		return nil, err
+	}
+
+	query := &sqlQuery{position: selectToken.Position}
+	if s.parser.peek().Type == model.DISTINCT {
+		s.parser.next()
+	}
+
+	if query.items, err = s.parseItems(); err != nil {
+		return nil, err
+	}
+
+	if _, err = s.parser.expect(model.FROM); err != nil {
+		return nil, err
+	}
+
+	if query.from, err = s.parseTables(); err != nil {
+		return nil, err
+	}
+
+	if s.parser.peek().Type == model.WHERE {
+		s.parser.next()
+		if query.where, err = s.parseDisjunction(); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.parser.peek().Type == model.GROUP {
+		if query.group, err = s.parseColumns(model.GROUP); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.parser.peek().Type == model.ORDER {
+		if query.order, err = s.parseColumns(model.ORDER); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.parser.peek().Type == model.LIMIT {
+		s.parser.next()
+		limit, err := s.parser.expect(model.INTEGER)
+		if err != nil {
+			return nil, err
+		}
+
+		query.limit = &IdentifierExpression{limit.Type.String(), limit.Value, limit.Position}
+	}
+
+	return query, nil
+}
+
+func (s *SQLParser) parseItems() ([]Expression, error) {
+	if s.parser.peek().Type == model.MULTIPLY {
+		s.parser.next()
+		return nil, nil
+	}
+
+	items := make([]Expression, 0)
+	for {
+		item, err := s.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+
+		if next := s.parser.peek(); next.Type == model.AS {
+			return nil, s.parser.error(next, "column aliases are not supported")
+		}
+
+		items = append(items, item)
+		if s.parser.peek().Type != model.COMMA {
+			return items, nil
+		}
+
+		s.parser.next()
+	}
+}
+
+func (s *SQLParser) parseTables() ([]sqlTable, error) {
+	tables := make([]sqlTable, 0)
+	for {
+		relation, err := s.parser.expect(model.FREE_RELATION)
+		if err != nil {
+			return nil, err
+		}
+
+		table := sqlTable{relation.Value, relation.Value, relation.Position}
+		if s.parser.peek().Type == model.AS {
+			s.parser.next()
+		}
+
+		if s.parser.peek().Type == model.FREE_RELATION {
+			name, _ := s.parser.next()
+			table.name = name.Value
+		}
+
+		tables = append(tables, table)
+		if s.parser.peek().Type != model.COMMA {
+			return tables, nil
+		}
+
+		s.parser.next()
+	}
+}
+
+// parseColumns parses GROUP BY or ORDER BY and its list of columns; the
+// columns of ORDER BY are wrapped in UP or DOWN as in ALPHA.
+func (s *SQLParser) parseColumns(clause model.LexType) ([]Expression, error) {
+	s.parser.next()
+	if _, err := s.parser.expect(model.BY); err != nil {
+		return nil, err
+	}
+
+	columns := make([]Expression, 0)
+	for {
+		token := s.parser.peek()
+		if token.Type != model.ATTRIBUTE && token.Type != model.FREE_RELATION {
+			return nil, s.parser.error(token, "expected a column, got %s", s.parser.describe(token))
+		}
+
+		s.parser.next()
+		var column Expression = &IdentifierExpression{token.Type.String(), token.Value, token.Position}
+		if clause == model.ORDER {
+			direction := model.UP
+			if next := s.parser.peek().Type; next == model.UP || next == model.DOWN {
+				s.parser.next()
+				direction = next
+			}
+
+			column = &UnaryExpression{direction.String(), column, token.Position}
+		}
+
+		columns = append(columns, column)
+		if s.parser.peek().Type != model.COMMA {
+			return columns, nil
+		}
+
+		s.parser.next()
+	}
+}
+
+func (s *SQLParser) parseDisjunction() (Expression, error) {
+	left, err := s.parseConjunction()
+	if err != nil {
+		return nil, err
+	}
+
+	for s.parser.peek().Type == model.DISJUNCTION {
+		operator, _ := s.parser.next()
+		right, err := s.parseConjunction()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &BinaryExpression{model.DISJUNCTION.String(), left, right, operator.Position}
+	}
+
+	return left, nil
+}
+
+func (s *SQLParser) parseConjunction() (Expression, error) {
+	left, err := s.parseNegation()
+	if err != nil {
+		return nil, err
+	}
+
+	for s.parser.peek().Type == model.AND {
+		operator, _ := s.parser.next()
+		right, err := s.parseNegation()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &BinaryExpression{model.CONJUNCTION.String(), left, right, operator.Position}
+	}
+
+	return left, nil
+}
+
+func (s *SQLParser) parseNegation() (Expression, error) {
+	if s.parser.peek().Type != model.NOT {
+		return s.parsePredicate()
+	}
+
+	operator, _ := s.parser.next()
+	expression, err := s.parseNegation()
+	if err != nil {
+		return nil, err
+	}
+
+	return &UnaryExpression{model.NEGATION.String(), expression, operator.Position}, nil
+}
+
+// parsePredicate parses a comparison, `IS [NOT] NULL`, or `[NOT] IN`,
+// `[NOT] BETWEEN` or `[NOT] LIKE`; EXISTS is parsed as a primary.
+func (s *SQLParser) parsePredicate() (Expression, error) {
+	left, err := s.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+
+	operators := []model.LexType{
+		model.EQUALS,
+		model.NOT_EQUALS,
+		model.LESS_THAN,
+		model.LESS_THAN_EQUALS,
+		model.GREATER_THAN,
+		model.GREATER_THAN_EQUALS,
+	}
+
+	switch operator := s.parser.peek(); {
+	case slices.Contains(operators, operator.Type):
+		s.parser.next()
+		right, err := s.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+
+		return &BinaryExpression{operator.Type.String(), left, right, operator.Position}, nil
+	case operator.Type == model.IS:
+		return s.parser.parseNullTest(left)
+	case operator.Type == model.NOT || operator.Type == model.IN || operator.Type == model.BETWEEN || operator.Type == model.LIKE:
+		return s.parseSetPredicate(left)
+	default:
+		return left, nil
+	}
+}
+
+func (s *SQLParser) parseSetPredicate(operand Expression) (Expression, error) {
+	not := s.parser.peek()
+	if not.Type == model.NOT {
+		s.parser.next()
+	}
+
+	operator, err := s.parser.next()
+	if err != nil {
+		return nil, err
+	}
+
+	var predicate Expression
+	switch operator.Type {
+	case model.IN:
+		if _, err = s.parser.expect(model.LEFT_PARENTHESIS); err != nil {
+			return nil, err
+		}
+
+		if s.parser.peek().Type == model.SELECT {
+			query, err := s.parseQuery()
+			if err != nil {
+				return nil, err
+			}
+
+			predicate = &SubqueryExpression{operator.Type.String(), operand, query, operator.Position}
+		} else {
+			values, err := s.parseValues()
+			if err != nil {
+				return nil, err
+			}
+
+			predicate = &InExpression{operator.Type.String(), operand, values, operator.Position}
+		}
+
+		if _, err = s.parser.expect(model.RIGHT_PARENTHESIS); err != nil {
+			return nil, err
+		}
+	case model.BETWEEN:
+		lower, err := s.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err = s.parser.expect(model.AND); err != nil {
+			return nil, err
+		}
+
+		upper, err := s.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+
+		predicate = &BetweenExpression{operator.Type.String(), operand, lower, upper, operator.Position}
+	case model.LIKE:
+		pattern := s.parser.peek()
+		if pattern.Type != model.CONSTANT && pattern.Type != model.DATE {
+			return nil, s.parser.error(pattern, "expected pattern string, got %s", s.parser.describe(pattern))
+		}
+
+		s.parser.next()
+		matcher, err := regexp.Compile(likeToRegexp(pattern.Value))
+		if err != nil {
+			return nil, s.parser.error(pattern, "invalid pattern %q: %s", pattern.Value, err)
+		}
+
+		value := &IdentifierExpression{pattern.Type.String(), pattern.Value, pattern.Position}
+		predicate = &PatternExpression{operator.Type.String(), operand, value, matcher, operator.Position}
+	default:
+		return nil, s.parser.error(operator, "expected IN, BETWEEN or LIKE, got %s", s.parser.describe(operator))
+	}
+
+	if not.Type == model.NOT {
+		return &UnaryExpression{model.NEGATION.String(), predicate, not.Position}, nil
+	}
+
+	return predicate, nil
+}
+
+func (s *SQLParser) parseValues() ([]Expression, error) {
+	values := make([]Expression, 0)
+	for {
+		value, err := s.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+		if s.parser.peek().Type != model.COMMA {
+			return values, nil
+		}
+
+		s.parser.next()
+	}
+}
+
+func (s *SQLParser) parseAdditive() (Expression, error) {
+	left, err := s.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+
+	for s.parser.peek().Type == model.PLUS || s.parser.peek().Type == model.MINUS {
+		operator, _ := s.parser.next()
+		right, err := s.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &BinaryExpression{operator.Type.String(), left, right, operator.Position}
+	}
+
+	return left, nil
+}
+
+func (s *SQLParser) parseMultiplicative() (Expression, error) {
+	left, err := s.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for s.parser.peek().Type == model.MULTIPLY || s.parser.peek().Type == model.DIVIDE {
+		operator, _ := s.parser.next()
+		right, err := s.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &BinaryExpression{operator.Type.String(), left, right, operator.Position}
+	}
+
+	return left, nil
+}
+
+func (s *SQLParser) parseUnary() (Expression, error) {
+	if s.parser.peek().Type != model.MINUS {
+		return s.parsePrimary()
+	}
+
+	operator, _ := s.parser.next()
+	expression, err := s.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &UnaryExpression{model.UNARY_MINUS.String(), expression, operator.Position}, nil
+}
+
+func (s *SQLParser) parsePrimary() (Expression, error) {
+	token, err := s.parser.next()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token.Type {
+	case model.FREE_RELATION:
+		// DATE '…', TIME '…' and TIMESTAMP '…' are the temporal literals.
+		if next := s.parser.peek(); next.Type == model.DATE && slices.Contains([]string{"DATE", "TIME", "TIMESTAMP"}, strings.ToUpper(token.Value)) {
+			s.parser.next()
+			return &IdentifierExpression{next.Type.String(), next.Value, token.Position}, nil
+		}
+
+		return &IdentifierExpression{token.Type.String(), token.Value, token.Position}, nil
+	case model.ATTRIBUTE, model.CONSTANT, model.INTEGER, model.FLOAT, model.DATE, model.NULL:
+		return &IdentifierExpression{token.Type.String(), token.Value, token.Position}, nil
+	case model.LEFT_PARENTHESIS:
+		var expression Expression
+		if s.parser.peek().Type == model.SELECT {
+			query, err := s.parseQuery()
+			if err != nil {
+				return nil, err
+			}
+
+			expression = &SubqueryExpression{model.SELECT.String(), nil, query, token.Position}
+		} else if expression, err = s.parseDisjunction(); err != nil {
+			return nil, err
+		}
+
+		if _, err = s.parser.expect(model.RIGHT_PARENTHESIS); err != nil {
+			return nil, err
+		}
+
+		return expression, nil
+	case model.EXISTS:
+		if _, err = s.parser.expect(model.LEFT_PARENTHESIS); err != nil {
+			return nil, err
+		}
+
+		query, err := s.parseQuery()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err = s.parser.expect(model.RIGHT_PARENTHESIS); err != nil {
+			return nil, err
+		}
+
+		return &SubqueryExpression{token.Type.String(), nil, query, token.Position}, nil
+	case model.COUNT, model.TOTAL, model.MAX, model.MIN, model.AVG:
+		return s.parseAggregate(token)
+	default:
+		return nil, s.parser.error(token, "unexpected %s", s.parser.describe(token))
+	}
+}
+
+// parseAggregate parses an aggregate function of a column, or COUNT(*),
+// whose argument is left empty.
+func (s *SQLParser) parseAggregate(function model.Token) (Expression, error) {
+	if _, err := s.parser.expect(model.LEFT_PARENTHESIS); err != nil {
+		return nil, err
+	}
+
+	aggregate := &FunctionExpression{kind: function.Type.String(), position: function.Position}
+	argument := s.parser.peek()
+	switch {
+	case argument.Type == model.MULTIPLY && function.Type == model.COUNT:
+	case argument.Type == model.ATTRIBUTE || argument.Type == model.FREE_RELATION:
+		aggregate.argument = &IdentifierExpression{argument.Type.String(), argument.Value, argument.Position}
+	default:
+		return nil, s.parser.error(argument, "expected a column, got %s", s.parser.describe(argument))
+	}
+
+	s.parser.next()
+	if _, err := s.parser.expect(model.RIGHT_PARENTHESIS); err != nil {
+		return nil, err
+	}
+
+	return aggregate, nil
+}
@@ -0,0 +1,551 @@
+package operation
+
+import (
+	"alpha-executor/entity"
+	"alpha-executor/model"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SQLTranslator turns ALPHA programs into standard SQL. A GET becomes a
+// SELECT DISTINCT over the ranges of its free variables; UP and DOWN become
+// ORDER BY and a row count becomes LIMIT, with the OFFSET the cursor of the
+// workspace has reached. A workspace that later statements refer to is
+// created as a view.
+//
+// Quantifiers become EXISTS and NOT EXISTS subqueries. EXISTS is two-valued,
+// so a quantifier is translated by its polarity: under an even number of
+// negations the subquery is TRUE exactly when the quantifier is TRUE, under an
+// odd number it is FALSE exactly when the quantifier is FALSE. Either way a
+// row is selected by SQL if and only if it is selected by ALPHA.
+type SQLTranslator struct {
+	ranges  map[string]string
+	columns map[string][]string
+	cursors map[string]int
+	views   map[string]bool
+	builder strings.Builder
+}
+
+func NewSQLTranslator(schemas entity.Schemas) *SQLTranslator {
+	columns := make(map[string][]string, len(schemas))
+	for name, schema := range schemas {
+		for _, attribute := range *schema {
+			columns[name] = append(columns[name], attribute.Name)
+		}
+	}
+
+	return &SQLTranslator{
+		ranges:  make(map[string]string),
+		columns: columns,
+		cursors: make(map[string]int),
+		views:   make(map[string]bool),
+	}
+}
+
+var sqlOperators = map[string]string{
+	model.NOT_EQUALS.String():  "<>",
+	model.CONJUNCTION.String(): "AND",
+	model.DISJUNCTION.String(): "OR",
+}
+
+var sqlTypes = map[entity.AttributeType]string{
+	entity.UnknownType:  "VARCHAR(255)",
+	entity.StringType:   "VARCHAR(255)",
+	entity.NumberType:   "NUMERIC",
+	entity.DateType:     "DATE",
+	entity.DateTimeType: "TIMESTAMP",
+	entity.TimeType:     "TIME",
+}
+
+// sqlKeywords are the reserved words that are likely to be used as names of
+// relations or attributes; such names are written as delimited identifiers.
+var sqlKeywords = map[string]bool{
+	"ALL": true, "AND": true, "ANY": true, "AS": true, "ASC": true, "BETWEEN": true, "BY": true,
+	"CASE": true, "CHECK": true, "COLUMN": true, "CREATE": true, "DATE": true, "DEFAULT": true,
+	"DELETE": true, "DESC": true, "DISTINCT": true, "ELSE": true, "END": true, "EXISTS": true,
+	"FALSE": true, "FROM": true, "GROUP": true, "HAVING": true, "IN": true, "INSERT": true,
+	"INTO": true, "IS": true, "JOIN": true, "KEY": true, "LIKE": true, "LIMIT": true, "NOT": true,
+	"NULL": true, "OFFSET": true, "ON": true, "OR": true, "ORDER": true, "PRIMARY": true,
+	"REFERENCES": true, "SELECT": true, "SET": true, "SOME": true, "TABLE": true, "THEN": true,
+	"TIME": true, "TIMESTAMP": true, "TRUE": true, "UNION": true, "UPDATE": true, "USER": true,
+	"VALUE": true, "VALUES": true, "VIEW": true, "WHEN": true, "WHERE": true,
+}
+
+var plainIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// TranslateData creates a table with the columns of its schema for every
+// relation and inserts its tuples, both in a canonical order.
+func (t *SQLTranslator) TranslateData(relations entity.Relations, schemas entity.Schemas) (string, error) {
+	t.builder.Reset()
+	names := make([]string, 0, len(relations))
+	for name := range relations {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		schema := *schemas[name]
+		columns := make([]string, len(schema))
+		t.write("CREATE TABLE %s (\n", identifier(name))
+		for i, attribute := range schema {
+			columns[i] = identifier(attribute.Name)
+			t.write("    %s %s", columns[i], sqlTypes[attribute.Type])
+			if !attribute.Nullable {
+				t.builder.WriteString(" NOT NULL")
+			}
+
+			if i < len(schema)-1 {
+				t.builder.WriteString(",")
+			}
+			t.builder.WriteString("\n")
+		}
+		t.builder.WriteString(");\n")
+
+		rows := make([]*entity.RowMap, 0, len(*relations[name]))
+		for row := range *relations[name] {
+			rows = append(rows, row)
+		}
+		slices.SortFunc(rows, compareRows)
+		if len(rows) == 0 {
+			t.builder.WriteString("\n")
+			continue
+		}
+
+		t.write("INSERT INTO %s (%s) VALUES\n", identifier(name), strings.Join(columns, ", "))
+		for i, row := range rows {
+			values := make([]string, len(schema))
+			for j, attribute := range schema {
+				value, err := t.value(name, attribute, (*row)[attribute.Name])
+				if err != nil {
+					return "", err
+				}
+
+				values[j] = value
+			}
+
+			t.write("    (%s)", strings.Join(values, ", "))
+			if i < len(rows)-1 {
+				t.builder.WriteString(",\n")
+			}
+		}
+		t.builder.WriteString(";\n\n")
+	}
+
+	return t.builder.String(), nil
+}
+
+func (t *SQLTranslator) value(relation string, attribute entity.SchemaAttribute, values []string) (string, error) {
+	switch {
+	case len(values) == 0:
+		return "NULL", nil
+	case len(values) > 1:
+		return "", &entity.CustomError{
+			ErrorType: entity.ResponseTypes["CF"],
+			Message:   fmt.Sprintf("attribute %s.%s has several values, which an SQL column cannot hold", relation, attribute.Name),
+		}
+	}
+
+	switch attribute.Type {
+	case entity.NumberType:
+		return values[0], nil
+	case entity.DateType, entity.DateTimeType, entity.TimeType:
+		return temporalLiteral(values[0]), nil
+	default:
+		return stringLiteral(values[0]), nil
+	}
+}
+
+// Translate writes every GET of a program as an SQL query, headed by the
+// ALPHA statement it was translated from.
+func (t *SQLTranslator) Translate(program *Program) (string, error) {
+	t.builder.Reset()
+	for i, expression := range program.body {
+		switch statement := expression.(type) {
+		case *RangeExpression:
+			variable := statement.variable.(*IdentifierExpression).value
+			t.ranges[variable] = t.resolve(statement.relation.(*IdentifierExpression).value)
+		case *GetHoldExpression:
+			if statement.kind != model.GET.String() {
+				return "", t.unsupported(statement.kind, statement.position)
+			}
+
+			later := make(map[string]bool)
+			for _, next := range program.body[i+1:] {
+				references(next, later)
+			}
+
+			t.get(statement, later)
+		default:
+			return "", t.unsupported(expression.GetKind(), positionOf(expression, entity.Position{}))
+		}
+	}
+
+	return t.builder.String(), nil
+}
+
+func (t *SQLTranslator) get(expression *GetHoldExpression, later map[string]bool) {
+	workspace := expression.variable.(*IdentifierExpression).value
+	free := make([]string, 0)
+	targets := make([]string, 0, len(expression.relations))
+	names := make([]string, 0, len(expression.relations))
+	for _, target := range expression.relations {
+		identifierTarget, isIdentifier := target.(*IdentifierExpression)
+		switch {
+		case isIdentifier && identifierTarget.kind == model.FREE_RELATION.String():
+			free = appendUnique(free, identifierTarget.value)
+			targets = append(targets, identifier(identifierTarget.value)+".*")
+			names = append(names, t.columns[t.resolve(identifierTarget.value)]...)
+		case isIdentifier && identifierTarget.kind == model.ATTRIBUTE.String():
+			variable, attribute, _ := strings.Cut(identifierTarget.value, ".")
+			free = appendUnique(free, variable)
+			targets = append(targets, t.operand(target))
+			names = append(names, attribute)
+		default:
+			for _, variable := range targetVariables(target) {
+				free = appendUnique(free, variable)
+			}
+
+			targets = append(targets, fmt.Sprintf("%s AS %s", t.operand(target), delimited(columnName(target))))
+			names = append(names, columnName(target))
+		}
+	}
+	t.columns[workspace] = names
+
+	printer := NewPrinter()
+	t.write("-- %s\n", printer.PrintExpression(expression))
+	if later[workspace] {
+		if t.views[workspace] {
+			t.write("DROP VIEW %s;\n", identifier(workspace))
+		}
+
+		t.views[workspace] = true
+		t.write("CREATE VIEW %s AS\n", identifier(workspace))
+	}
+
+	t.write("SELECT DISTINCT %s", strings.Join(targets, ", "))
+	if len(free) > 0 {
+		from := make([]string, len(free))
+		for i, variable := range free {
+			from[i] = t.from(variable)
+		}
+
+		t.write("\nFROM %s", strings.Join(from, ", "))
+	}
+
+	if expression.expression != nil {
+		t.write("\nWHERE %s", t.condition(expression.expression, true))
+	}
+
+	order := make([]string, 0, len(expression.sort))
+	for _, key := range expression.sort {
+		sortKey := key.(*UnaryExpression)
+		direction := "ASC"
+		if sortKey.kind == model.DOWN.String() {
+			direction = "DESC"
+		}
+
+		order = append(order, fmt.Sprintf("%s %s", t.operand(sortKey.expression), direction))
+	}
+
+	if expression.rows == nil {
+		delete(t.cursors, workspace)
+	} else if len(order) == 0 {
+		// ALPHA takes the rows of an unsorted workspace in canonical order,
+		// which compares the attributes by name.
+		for _, name := range sorted(names) {
+			order = append(order, identifier(name)+" ASC")
+		}
+	}
+
+	if len(order) > 0 {
+		t.write("\nORDER BY %s", strings.Join(order, ", "))
+	}
+
+	if expression.rows != nil {
+		rows := expression.rows.(*IdentifierExpression).value
+		t.write("\nLIMIT %s", rows)
+		if offset := t.cursors[workspace]; offset > 0 {
+			t.write(" OFFSET %d", offset)
+		}
+
+		count, _ := strconv.Atoi(rows)
+		t.cursors[workspace] += count
+	}
+
+	t.builder.WriteString(";\n\n")
+}
+
+// condition translates a qualification; positive tells whether it is under
+// an even number of negations.
+func (t *SQLTranslator) condition(expression Expression, positive bool) string {
+	switch node := expression.(type) {
+	case *BinaryExpression:
+		switch node.kind {
+		case model.EXISTS.String(), model.FOR_ALL.String():
+			return t.quantifier(node, positive)
+		case model.CONJUNCTION.String(), model.DISJUNCTION.String():
+			return fmt.Sprintf("%s %s %s", t.grouped(node.left, positive), sqlOperators[node.kind], t.grouped(node.right, positive))
+		case model.IMPLICATION.String():
+			return fmt.Sprintf("NOT %s OR %s", t.grouped(node.left, !positive), t.grouped(node.right, positive))
+		}
+
+		operator := node.kind
+		if sqlOperator, exists := sqlOperators[operator]; exists {
+			operator = sqlOperator
+		}
+
+		return fmt.Sprintf("%s %s %s", t.operand(node.left), operator, t.operand(node.right))
+	case *UnaryExpression:
+		switch node.kind {
+		case model.NEGATION.String():
+			return fmt.Sprintf("NOT (%s)", t.condition(node.expression, !positive))
+		case model.IS_NULL.String(), model.IS_NOT_NULL.String():
+			return fmt.Sprintf("%s %s", t.operand(node.expression), node.kind)
+		}
+	case *InExpression:
+		values := make([]string, len(node.values))
+		for i, value := range node.values {
+			values[i] = t.operand(value)
+		}
+
+		return fmt.Sprintf("%s IN (%s)", t.operand(node.operand), strings.Join(values, ", "))
+	case *BetweenExpression:
+		return fmt.Sprintf("%s BETWEEN %s AND %s", t.operand(node.operand), t.operand(node.lower), t.operand(node.upper))
+	case *PatternExpression:
+		// SQL has no common regular expression predicate; ~ is PostgreSQL's.
+		return fmt.Sprintf("%s %s %s", t.operand(node.operand), node.kind, stringLiteral(node.pattern.value))
+	}
+
+	return t.operand(expression)
+}
+
+func (t *SQLTranslator) quantifier(node *BinaryExpression, positive bool) string {
+	from := t.from(node.left.(*IdentifierExpression).value)
+	body := t.condition(node.right, positive)
+	switch {
+	case node.kind == model.EXISTS.String() && positive:
+		return fmt.Sprintf("EXISTS (SELECT * FROM %s WHERE %s)", from, body)
+	case node.kind == model.EXISTS.String():
+		return fmt.Sprintf("EXISTS (SELECT * FROM %s WHERE (%s) IS NOT FALSE)", from, body)
+	case positive:
+		return fmt.Sprintf("NOT EXISTS (SELECT * FROM %s WHERE (%s) IS NOT TRUE)", from, body)
+	default:
+		return fmt.Sprintf("NOT EXISTS (SELECT * FROM %s WHERE (%s) IS FALSE)", from, body)
+	}
+}
+
+// grouped parenthesises an operand of a connective unless it delimits itself.
+func (t *SQLTranslator) grouped(expression Expression, positive bool) string {
+	switch expression.GetKind() {
+	case model.NEGATION.String(), model.EXISTS.String(), model.FOR_ALL.String():
+		return t.condition(expression, positive)
+	}
+
+	return fmt.Sprintf("(%s)", t.condition(expression, positive))
+}
+
+func (t *SQLTranslator) operand(expression Expression) string {
+	switch node := expression.(type) {
+	case *IdentifierExpression:
+		switch node.kind {
+		case model.ATTRIBUTE.String():
+			variable, attribute, _ := strings.Cut(node.value, ".")
+			return identifier(variable) + "." + identifier(attribute)
+		case model.CONSTANT.String():
+			return stringLiteral(node.value)
+		case model.INTEGER.String(), model.FLOAT.String():
+			return node.value
+		case model.DATE.String():
+			return temporalLiteral(node.value)
+		case model.NULL.String():
+			return "NULL"
+		default:
+			return identifier(node.value)
+		}
+	case *FunctionExpression:
+		return t.aggregate(node)
+	case *BinaryExpression:
+		return fmt.Sprintf("%s %s %s", t.arithmetic(node.left), node.kind, t.arithmetic(node.right))
+	case *UnaryExpression:
+		return "-" + t.arithmetic(node.expression)
+	default:
+		return expression.GetKind()
+	}
+}
+
+func (t *SQLTranslator) arithmetic(expression Expression) string {
+	if _, isBinary := expression.(*BinaryExpression); isBinary {
+		return fmt.Sprintf("(%s)", t.operand(expression))
+	}
+
+	return t.operand(expression)
+}
+
+// aggregate translates a function into a scalar subquery over the range of
+// its argument. TOTAL of no values is 0, whereas SUM of no values is NULL.
+func (t *SQLTranslator) aggregate(function *FunctionExpression) string {
+	argument := function.argument.(*IdentifierExpression)
+	variable, _, _ := strings.Cut(argument.value, ".")
+
+	var aggregate string
+	switch {
+	case argument.kind != model.ATTRIBUTE.String():
+		aggregate = "COUNT(*)"
+	case function.kind == model.TOTAL.String():
+		aggregate = fmt.Sprintf("COALESCE(SUM(%s), 0)", t.operand(argument))
+	default:
+		aggregate = fmt.Sprintf("%s(%s)", function.kind, t.operand(argument))
+	}
+
+	query := fmt.Sprintf("(SELECT %s FROM %s", aggregate, t.from(variable))
+	if function.qualification != nil {
+		query += " WHERE " + t.condition(function.qualification, true)
+	}
+
+	return query + ")"
+}
+
+// from names the range of a variable in a FROM clause.
+func (t *SQLTranslator) from(variable string) string {
+	relation := t.resolve(variable)
+	if relation == variable {
+		return identifier(relation)
+	}
+
+	return fmt.Sprintf("%s AS %s", identifier(relation), identifier(variable))
+}
+
+func (t *SQLTranslator) resolve(variable string) string {
+	if relation, exists := t.ranges[variable]; exists {
+		return relation
+	}
+
+	return variable
+}
+
+func (t *SQLTranslator) unsupported(construct string, position entity.Position) error {
+	return &entity.CustomError{
+		ErrorType: entity.ResponseTypes["CE"],
+		Message:   fmt.Sprintf("%s cannot be translated into SQL", construct),
+		Position:  position,
+	}
+}
+
+func (t *SQLTranslator) write(format string, args ...any) {
+	_, _ = fmt.Fprintf(&t.builder, format, args...)
+}
+
+// targetVariables returns the variables of the attributes of a computed
+// target, leaving out the arguments of functions, which bind their own.
+func targetVariables(expression Expression) []string {
+	switch node := expression.(type) {
+	case *IdentifierExpression:
+		if node.kind == model.ATTRIBUTE.String() {
+			variable, _, _ := strings.Cut(node.value, ".")
+			return []string{variable}
+		}
+	case *BinaryExpression:
+		return append(targetVariables(node.left), targetVariables(node.right)...)
+	case *UnaryExpression:
+		return targetVariables(node.expression)
+	}
+
+	return nil
+}
+
+// references collects the relations and workspaces a statement refers to.
+func references(expression Expression, names map[string]bool) {
+	switch node := expression.(type) {
+	case *IdentifierExpression:
+		switch node.kind {
+		case model.ATTRIBUTE.String():
+			variable, _, _ := strings.Cut(node.value, ".")
+			names[variable] = true
+		case model.FREE_RELATION.String(), model.BIND_RELATION.String():
+			names[node.value] = true
+		}
+	case *GetHoldExpression:
+		for _, target := range node.relations {
+			references(target, names)
+		}
+
+		if node.expression != nil {
+			references(node.expression, names)
+		}
+	case *RangeExpression:
+		references(node.relation, names)
+	case *PutExpression:
+		references(node.variable, names)
+	case *BinaryExpression:
+		references(node.left, names)
+		references(node.right, names)
+	case *UnaryExpression:
+		references(node.expression, names)
+	case *FunctionExpression:
+		references(node.argument, names)
+		if node.qualification != nil {
+			references(node.qualification, names)
+		}
+	case *InExpression:
+		references(node.operand, names)
+		for _, value := range node.values {
+			references(value, names)
+		}
+	case *BetweenExpression:
+		references(node.operand, names)
+		references(node.lower, names)
+		references(node.upper, names)
+	case *PatternExpression:
+		references(node.operand, names)
+	}
+}
+
+func sorted(values []string) []string {
+	values = slices.Clone(values)
+	slices.Sort(values)
+	return values
+}
+
+func appendUnique(values []string, value string) []string {
+	if slices.Contains(values, value) {
+		return values
+	}
+
+	return append(values, value)
+}
+
+// identifier writes a name as a delimited identifier only when it has to be.
+func identifier(name string) string {
+	if plainIdentifier.MatchString(name) && !sqlKeywords[strings.ToUpper(name)] {
+		return name
+	}
+
+	return delimited(name)
+}
+
+func delimited(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func stringLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+func temporalLiteral(value string) string {
+	temporal, isTemporal := entity.ParseTemporal(value)
+	if !isTemporal {
+		return stringLiteral(value)
+	}
+
+	switch temporal.Kind {
+	case entity.DateKind:
+		return fmt.Sprintf("DATE '%s'", temporal.Value.Format("2006-01-02"))
+	case entity.TimeKind:
+		return fmt.Sprintf("TIME '%s'", temporal.Value.Format("15:04:05"))
+	default:
+		return fmt.Sprintf("TIMESTAMP '%s'", temporal.Value.Format("2006-01-02 15:04:05"))
+	}
+}
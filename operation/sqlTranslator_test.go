@@ -0,0 +1,140 @@
+package operation
+
+import (
+	"alpha-executor/entity"
+	"bufio"
+	"strings"
+	"testing"
+)
+
+const translated = `{
+	"EMP": [
+		{"id": ["1"], "name": ["O'Neil"], "salary": ["100"], "hired": ["2000-02-01"]},
+		{"id": ["2"], "name": ["bob"], "salary": null, "hired": ["2001-02-01"]}
+	],
+	"DEPT": [{"dept": ["d1"], "order": ["x"]}]
+}`
+
+// translate writes the data and an ALPHA program in SQL as the SQL endpoint
+// does.
+func translate(t *testing.T, data, query string) (string, string) {
+	t.Helper()
+	program, errors := GenerateAST(bufio.NewReader(strings.NewReader(query)))
+	if len(errors) > 0 {
+		t.Fatalf("%s: %v", query, entity.CustomErrors(errors))
+	}
+
+	relations := parseRelations(t, data)
+	schemas, err := entity.NewSchemas(relations, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if errors = NewBinder(schemas).Bind(&program); len(errors) > 0 {
+		t.Fatalf("%s: %v", query, entity.CustomErrors(errors))
+	}
+
+	translator := NewSQLTranslator(schemas)
+	tables, err := translator.TranslateData(relations, schemas)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	queries, err := translator.Translate(&program)
+	if err != nil {
+		t.Fatalf("%s: %v", query, err)
+	}
+
+	return tables, queries
+}
+
+func TestTranslateData(t *testing.T) {
+	tables, _ := translate(t, translated, `GET W (EMP)`)
+	expected := `CREATE TABLE DEPT (
+    dept VARCHAR(255) NOT NULL,
+    "order" VARCHAR(255) NOT NULL
+);
+INSERT INTO DEPT (dept, "order") VALUES
+    ('d1', 'x');
+
+CREATE TABLE EMP (
+    hired DATE NOT NULL,
+    id NUMERIC NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    salary NUMERIC
+);
+INSERT INTO EMP (hired, id, name, salary) VALUES
+    (DATE '2000-02-01', 1, 'O''Neil', 100),
+    (DATE '2001-02-01', 2, 'bob', NULL);
+
+`
+	if tables != expected {
+		t.Errorf("got\n%s\nwant\n%s", tables, expected)
+	}
+}
+
+func TestTranslateQueries(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{
+			`GET W (EMP.name): EMP.salary > 95`,
+			"-- GET W (EMP.name): EMP.salary > 95\n" +
+				"SELECT DISTINCT EMP.name\nFROM EMP\nWHERE EMP.salary > 95;\n\n",
+		},
+		{
+			`RANGE EMP E; GET W (DEPT.dept): ∃E (E.id = 1) DOWN DEPT.dept`,
+			"-- GET W (DEPT.dept): ∃E(E.id = 1) DOWN DEPT.dept\n" +
+				"SELECT DISTINCT DEPT.dept\nFROM DEPT\nWHERE EXISTS (SELECT * FROM EMP AS E WHERE E.id = 1)\n" +
+				"ORDER BY DEPT.dept DESC;\n\n",
+		},
+		{
+			`RANGE EMP E; GET W (DEPT.dept): ∀E (E.salary > 95)`,
+			"-- GET W (DEPT.dept): ∀E(E.salary > 95)\n" +
+				"SELECT DISTINCT DEPT.dept\nFROM DEPT\n" +
+				"WHERE NOT EXISTS (SELECT * FROM EMP AS E WHERE (E.salary > 95) IS NOT TRUE);\n\n",
+		},
+		{
+			`RANGE EMP E; GET W (DEPT.dept): ¬∃E (E.salary > 95)`,
+			"-- GET W (DEPT.dept): ¬(∃E(E.salary > 95))\n" +
+				"SELECT DISTINCT DEPT.dept\nFROM DEPT\n" +
+				"WHERE NOT (EXISTS (SELECT * FROM EMP AS E WHERE (E.salary > 95) IS NOT FALSE));\n\n",
+		},
+		{
+			`GET W (2) (EMP.id, EMP.salary * 2) DOWN EMP.id; GET W (2) (EMP.id, EMP.salary * 2) DOWN EMP.id`,
+			"-- GET W (2) (EMP.id, EMP.salary * 2) DOWN EMP.id\n" +
+				"SELECT DISTINCT EMP.id, EMP.salary * 2 AS \"EMP.salary * 2\"\nFROM EMP\nORDER BY EMP.id DESC\nLIMIT 2;\n\n" +
+				"-- GET W (2) (EMP.id, EMP.salary * 2) DOWN EMP.id\n" +
+				"SELECT DISTINCT EMP.id, EMP.salary * 2 AS \"EMP.salary * 2\"\nFROM EMP\nORDER BY EMP.id DESC\nLIMIT 2 OFFSET 2;\n\n",
+		},
+		{
+			`GET W (EMP.id); RANGE W X; GET V (X.id)`,
+			"-- GET W (EMP.id)\nCREATE VIEW W AS\nSELECT DISTINCT EMP.id\nFROM EMP;\n\n" +
+				"-- GET V (X.id)\nSELECT DISTINCT X.id\nFROM W AS X;\n\n",
+		},
+		{
+			`GET W (EMP.id, COUNT(EMP WHERE EMP.salary IS NULL))`,
+			"-- GET W (EMP.id, COUNT(EMP WHERE EMP.salary IS NULL))\n" +
+				"SELECT DISTINCT EMP.id, (SELECT COUNT(*) FROM EMP WHERE EMP.salary IS NULL) AS \"COUNT(EMP)\"\nFROM EMP;\n\n",
+		},
+		{`GET W (DEPT.order)`, "-- GET W (DEPT.order)\nSELECT DISTINCT DEPT.\"order\"\nFROM DEPT;\n\n"},
+	}
+
+	for _, test := range tests {
+		if _, queries := translate(t, translated, test.query); queries != test.expected {
+			t.Errorf("%s: got\n%s\nwant\n%s", test.query, queries, test.expected)
+		}
+	}
+}
+
+func TestTranslateDataWithSeveralValues(t *testing.T) {
+	relations := parseRelations(t, `{"EMP": [{"id": ["1", "2"]}]}`)
+	schemas, err := entity.NewSchemas(relations, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = NewSQLTranslator(schemas).TranslateData(relations, schemas)
+	expectError(t, err, "attribute EMP.id has several values, which an SQL column cannot hold")
+}
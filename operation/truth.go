@@ -0,0 +1,47 @@
+package operation
+
+// TruthValue is a value of the three-valued logic used by qualifications:
+// a comparison involving NULL is neither true nor false but UNKNOWN.
+// The values are ordered so that conjunction is the minimum and disjunction
+// the maximum of the operands.
+type TruthValue int
+
+const (
+	FALSE TruthValue = iota
+	UNKNOWN
+	TRUE
+)
+
+var truthValues = []string{
+	FALSE:   "FALSE",
+	UNKNOWN: "UNKNOWN",
+	TRUE:    "TRUE",
+}
+
+func (t TruthValue) String() string {
+	return truthValues[t]
+}
+
+func truthOf(value bool) TruthValue {
+	if value {
+		return TRUE
+	}
+
+	return FALSE
+}
+
+func (t TruthValue) And(other TruthValue) TruthValue {
+	return min(t, other)
+}
+
+func (t TruthValue) Or(other TruthValue) TruthValue {
+	return max(t, other)
+}
+
+func (t TruthValue) Not() TruthValue {
+	return TRUE - t
+}
+
+func (t TruthValue) Implies(other TruthValue) TruthValue {
+	return t.Not().Or(other)
+}
@@ -0,0 +1,76 @@
+package operation
+
+import "testing"
+
+func TestTruthTables(t *testing.T) {
+	values := []TruthValue{FALSE, UNKNOWN, TRUE}
+	and := [3][3]TruthValue{
+		{FALSE, FALSE, FALSE},
+		{FALSE, UNKNOWN, UNKNOWN},
+		{FALSE, UNKNOWN, TRUE},
+	}
+	or := [3][3]TruthValue{
+		{FALSE, UNKNOWN, TRUE},
+		{UNKNOWN, UNKNOWN, TRUE},
+		{TRUE, TRUE, TRUE},
+	}
+	implies := [3][3]TruthValue{
+		{TRUE, TRUE, TRUE},
+		{UNKNOWN, UNKNOWN, TRUE},
+		{FALSE, UNKNOWN, TRUE},
+	}
+	not := [3]TruthValue{TRUE, UNKNOWN, FALSE}
+
+	for i, left := range values {
+		if got := left.Not(); got != not[i] {
+			t.Errorf("¬%s = %s, want %s", left, got, not[i])
+		}
+
+		for j, right := range values {
+			if got := left.And(right); got != and[i][j] {
+				t.Errorf("%s ∧ %s = %s, want %s", left, right, got, and[i][j])
+			}
+
+			if got := left.Or(right); got != or[i][j] {
+				t.Errorf("%s ∨ %s = %s, want %s", left, right, got, or[i][j])
+			}
+
+			if got := left.Implies(right); got != implies[i][j] {
+				t.Errorf("%s → %s = %s, want %s", left, right, got, implies[i][j])
+			}
+		}
+	}
+}
+
+const nullEmployees = `{
+	"EMP": [
+		{"id": ["1"], "dept": ["d1"], "salary": ["100"]},
+		{"id": ["2"], "dept": ["d1"], "salary": null},
+		{"id": ["3"], "dept": [], "salary": ["90"]}
+	],
+	"DEPT": [{"dept": ["d1"]}, {"dept": ["d2"]}]
+}`
+
+func TestNullQualifications(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{`GET W (EMP.id): EMP.salary IS NULL`, `[{"id": ["2"]}]`},
+		{`GET W (EMP.id): EMP.dept IS NOT NULL`, `[{"id": ["1"]}, {"id": ["2"]}]`},
+		{`GET W (EMP.id): EMP.salary > 95`, `[{"id": ["1"]}]`},
+		{`GET W (EMP.id): ¬(EMP.salary > 95)`, `[{"id": ["3"]}]`},
+		{`GET W (EMP.id): EMP.salary > 95 ∨ EMP.salary <= 95`, `[{"id": ["1"]}, {"id": ["3"]}]`},
+		{`GET W (EMP.id): EMP.salary > 95 ∨ EMP.dept = "d1"`, `[{"id": ["1"]}, {"id": ["2"]}]`},
+		{`GET W (EMP.id): EMP.salary > 95 -> EMP.dept = "d1"`, `[{"id": ["1"]}, {"id": ["2"]}, {"id": ["3"]}]`},
+		{`GET W (EMP.id): EMP.salary = NULL`, `[]`},
+		{`RANGE EMP E; GET W (DEPT.dept): ∀E (E.dept = DEPT.dept)`, `[]`},
+		{`RANGE EMP E; GET W (DEPT.dept): ¬∃E (E.dept = DEPT.dept)`, `[]`},
+		{`RANGE EMP E; GET W (DEPT.dept): ¬∃E (E.dept = DEPT.dept ∧ E.dept IS NOT NULL)`, `[{"dept": ["d2"]}]`},
+		{`RANGE EMP E; GET W (DEPT.dept): ∀E (E.salary > 80)`, `[]`},
+	}
+
+	for _, test := range tests {
+		expectWorkspace(t, nullEmployees, test.query, "W", test.expected)
+	}
+}
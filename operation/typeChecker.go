@@ -0,0 +1,170 @@
+package operation
+
+import (
+	"alpha-executor/entity"
+	"alpha-executor/model"
+	"fmt"
+)
+
+// TypeChecker reports comparisons and arithmetic over incompatible attribute
+// types before a program is interpreted. Names it cannot resolve are left
+// to the interpreter.
+type TypeChecker struct {
+	scope  *scope
+	errors []*entity.CustomError
+}
+
+func NewTypeChecker(schemas entity.Schemas) *TypeChecker {
+	return &TypeChecker{
+		scope:  newScope(schemas),
+		errors: make([]*entity.CustomError, 0),
+	}
+}
+
+func (t *TypeChecker) Check(program *Program) []*entity.CustomError {
+	for _, expression := range program.body {
+		switch statement := expression.(type) {
+		case *RangeExpression:
+			relation := statement.relation.(*IdentifierExpression).value
+			t.scope.declareRange(statement.variable.(*IdentifierExpression).value, relation)
+		case *GetHoldExpression:
+			for _, target := range statement.relations {
+				t.typeOf(target)
+			}
+
+			if statement.expression != nil {
+				t.checkQualification(statement.expression)
+			}
+
+			if statement.kind == model.GET.String() {
+				name := statement.variable.(*IdentifierExpression).value
+				t.scope.declareWorkspace(name, t.scope.workspaceSchema(statement.relations, t.typeOf))
+			}
+		}
+	}
+
+	return t.errors
+}
+
+func (t *TypeChecker) checkQualification(expression Expression) {
+	switch qualification := expression.(type) {
+	case *BinaryExpression:
+		if comparisonKinds[qualification.kind] {
+			t.checkComparison(qualification)
+			return
+		}
+
+		if qualification.kind != model.EXISTS.String() && qualification.kind != model.FOR_ALL.String() {
+			t.checkQualification(qualification.left)
+		}
+
+		t.checkQualification(qualification.right)
+	case *UnaryExpression:
+		t.checkQualification(qualification.expression)
+	case *InExpression:
+		for _, value := range qualification.values {
+			t.checkOperands(qualification.operand, value, qualification.position)
+		}
+	case *BetweenExpression:
+		t.checkOperands(qualification.operand, qualification.lower, qualification.position)
+		t.checkOperands(qualification.operand, qualification.upper, qualification.position)
+	case *PatternExpression:
+		t.typeOf(qualification.operand)
+	default:
+		t.typeOf(expression)
+	}
+}
+
+func (t *TypeChecker) checkComparison(expression *BinaryExpression) {
+	t.checkOperands(expression.left, expression.right, expression.position)
+}
+
+func (t *TypeChecker) checkOperands(leftOperand, rightOperand Expression, position entity.Position) {
+	left := t.typeOf(leftOperand)
+	right := t.typeOf(rightOperand)
+	if !entity.TypesCompatible(left, right) {
+		t.error(position, "cannot compare %s with %s", left, right)
+	}
+}
+
+func (t *TypeChecker) typeOf(expression Expression) entity.AttributeType {
+	switch operand := expression.(type) {
+	case *IdentifierExpression:
+		switch operand.kind {
+		case model.ATTRIBUTE.String():
+			return t.attributeType(operand)
+		case model.CONSTANT.String(), model.DATE.String(), model.INTEGER.String(), model.FLOAT.String():
+			return entity.InferType(operand.value)
+		default:
+			return entity.UnknownType
+		}
+	case *BinaryExpression:
+		if !isArithmetic(operand.kind) {
+			return entity.UnknownType
+		}
+
+		t.checkNumeric(operand.left, operand.position)
+		t.checkNumeric(operand.right, operand.position)
+		return entity.NumberType
+	case *UnaryExpression:
+		if operand.kind != model.UNARY_MINUS.String() {
+			return entity.UnknownType
+		}
+
+		t.checkNumeric(operand.expression, operand.position)
+		return entity.NumberType
+	case *FunctionExpression:
+		if operand.qualification != nil {
+			t.checkQualification(operand.qualification)
+		}
+
+		argument := t.typeOf(operand.argument)
+		switch operand.kind {
+		case model.MAX.String(), model.MIN.String():
+			return argument
+		case model.TOTAL.String(), model.AVG.String():
+			t.checkNumeric(operand.argument, operand.position)
+		}
+
+		return entity.NumberType
+	default:
+		return entity.UnknownType
+	}
+}
+
+func (t *TypeChecker) checkNumeric(expression Expression, position entity.Position) {
+	if operandType := t.typeOf(expression); !entity.TypesCompatible(operandType, entity.NumberType) {
+		t.error(position, "arithmetic on %s value", operandType)
+	}
+}
+
+func (t *TypeChecker) attributeType(identifier *IdentifierExpression) entity.AttributeType {
+	schemaAttribute, _ := t.scope.attribute(identifier)
+	return schemaAttribute.Type
+}
+
+// error reports an error once, however often the expression it is found in
+// is checked.
+func (t *TypeChecker) error(position entity.Position, format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	for _, err := range t.errors {
+		if err.Position == position && err.Message == message {
+			return
+		}
+	}
+
+	t.errors = append(t.errors, &entity.CustomError{
+		ErrorType: entity.ResponseTypes["CE"],
+		Message:   message,
+		Position:  position,
+	})
+}
+
+var comparisonKinds = map[string]bool{
+	model.EQUALS.String():              true,
+	model.NOT_EQUALS.String():          true,
+	model.LESS_THAN.String():           true,
+	model.LESS_THAN_EQUALS.String():    true,
+	model.GREATER_THAN.String():        true,
+	model.GREATER_THAN_EQUALS.String(): true,
+}
@@ -0,0 +1,51 @@
+package operation
+
+import (
+	"strings"
+	"testing"
+)
+
+const typed = `{"EMP": [{"id": ["1"], "name": ["ann"], "hired": ["2000-02-01"], "salary": ["100"]}]}`
+
+func TestTypeCheckerRejectsIncompatibleComparisons(t *testing.T) {
+	tests := []struct {
+		query   string
+		message string
+	}{
+		{`GET W (EMP.id): EMP.name > "2000-01-01"`, "cannot compare string with date at 1 line, 26 column"},
+		{`GET W (EMP.id): EMP.salary = "ann"`, "cannot compare number with string at 1 line, 28 column"},
+		{`RANGE EMP E; GET W (E.id): E.hired = 5`, "cannot compare date with number at 1 line, 36 column"},
+		{`GET W (EMP.id): MAX(EMP.name) > 1`, "cannot compare string with number"},
+		{`GET W (EMP.id): TOTAL(EMP.hired) > 1`, "arithmetic on date value"},
+	}
+
+	for _, test := range tests {
+		_, _, err := run(t, typed, test.query)
+		expectError(t, err, "Compilation Error: "+test.message)
+	}
+}
+
+func TestTypeCheckerAcceptsCompatibleComparisons(t *testing.T) {
+	expectWorkspace(t, typed, `GET W (EMP.id): EMP.hired < "2000-02-01 10:00:00"`, "W", `[{"id": ["1"]}]`)
+	expectWorkspace(t, typed, `GET W (EMP.id): EMP.salary * 2 = 200 ∧ EMP.name = "ann"`, "W", `[{"id": ["1"]}]`)
+}
+
+func TestTypeCheckerReportsEveryStatement(t *testing.T) {
+	_, _, err := run(t, typed, `GET W (EMP.id): EMP.salary > EMP.hired; GET V (EMP.id): EMP.name + 1 > 2`)
+	expectError(t, err, "cannot compare number with date")
+	expectError(t, err, "arithmetic on string value")
+}
+
+func TestTypeCheckerReportsDistinctErrorsAtOnePosition(t *testing.T) {
+	_, _, err := run(t, typed, `GET W (EMP.name): EMP.name + EMP.hired > 1`)
+	expectError(t, err, "arithmetic on string value")
+	expectError(t, err, "arithmetic on date value")
+}
+
+func TestTypeCheckerReportsAnErrorOnce(t *testing.T) {
+	_, _, err := run(t, typed, `GET W (EMP.name): EMP.name + EMP.name > 1`)
+	expectError(t, err, "arithmetic on string value")
+	if err != nil && strings.Count(err.Error(), "arithmetic on string value") != 1 {
+		t.Errorf("got %v, want the error once", err)
+	}
+}
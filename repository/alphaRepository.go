@@ -10,7 +10,10 @@ type AlphaRepository struct {
 	relations           entity.Relations
 	calculatedRelations entity.Relations
 	heldRelations       entity.Relations
-	getRelations        entity.Relations
+	heldSources         map[string]string
+	heldTuples          map[string][]*entity.RowMap
+	getRelations        entity.Workspaces
+	cursors             map[string]int
 }
 
 func NewAlphaRepository(
@@ -18,14 +21,20 @@ func NewAlphaRepository(
 	relations entity.Relations,
 	calculatedRelations entity.Relations,
 	heldRelations entity.Relations,
-	getRelations entity.Relations,
+	heldSources map[string]string,
+	heldTuples map[string][]*entity.RowMap,
+	getRelations entity.Workspaces,
+	cursors map[string]int,
 ) *AlphaRepository {
 	return &AlphaRepository{
 		rows:                rows,
 		relations:           relations,
 		calculatedRelations: calculatedRelations,
 		heldRelations:       heldRelations,
+		heldSources:         heldSources,
+		heldTuples:          heldTuples,
 		getRelations:        getRelations,
+		cursors:             cursors,
 	}
 }
 
@@ -45,6 +54,10 @@ func (t *AlphaRepository) GetRow(name string) (*entity.RowMap, error) {
 	}
 }
 
+func (t *AlphaRepository) DeleteRow(name string) {
+	delete(t.rows, name)
+}
+
 func (t *AlphaRepository) AddRelation(name string, relation *entity.Relation) {
 	t.relations[name] = relation
 }
@@ -89,8 +102,12 @@ func (t *AlphaRepository) GetCalculatedRelation(name string) (*entity.Relation,
 	}
 }
 
-func (t *AlphaRepository) AddHeldRelation(name string, relation *entity.Relation) {
+// AddHeldRelation holds a workspace together with the tuples of its source
+// relation it was projected from, which DELETE removes.
+func (t *AlphaRepository) AddHeldRelation(name string, source string, relation *entity.Relation, tuples []*entity.RowMap) {
 	t.heldRelations[name] = relation
+	t.heldSources[name] = source
+	t.heldTuples[name] = tuples
 }
 
 func (t *AlphaRepository) GetHeldRelation(name string) (*entity.Relation, error) {
@@ -105,16 +122,58 @@ func (t *AlphaRepository) GetHeldRelation(name string) (*entity.Relation, error)
 	}
 }
 
-func (t *AlphaRepository) AddGetRelation(name string, relation *entity.Relation) {
-	t.getRelations[name] = relation
+func (t *AlphaRepository) GetHeldSource(name string) (string, error) {
+	result := t.heldSources[name]
+	if result != "" {
+		return result, nil
+	}
+
+	return "", &entity.CustomError{
+		ErrorType: entity.ResponseTypes["RT"],
+		Message:   fmt.Sprintf("relation %s isn't held from a single relation", name),
+	}
+}
+
+func (t *AlphaRepository) GetHeldTuples(name string) []*entity.RowMap {
+	return t.heldTuples[name]
+}
+
+func (t *AlphaRepository) AddGetRelation(name string, workspace *entity.Workspace) {
+	t.getRelations[name] = workspace
 }
 
-func (t *AlphaRepository) GetGetRelations() entity.Relations {
+func (t *AlphaRepository) GetGetRelation(name string) (*entity.Relation, error) {
+	result := t.getRelations[name]
+	if result != nil {
+		return result.Relation, nil
+	}
+
+	return nil, &entity.CustomError{
+		ErrorType: entity.ResponseTypes["CE"],
+		Message:   fmt.Sprintf("relation %s is null", name),
+	}
+}
+
+func (t *AlphaRepository) GetGetRelations() entity.Workspaces {
 	return t.getRelations
 }
 
+// AdvanceCursor moves the cursor of a workspace count tuples forward and
+// returns its previous offset.
+func (t *AlphaRepository) AdvanceCursor(name string, count int) int {
+	offset := t.cursors[name]
+	t.cursors[name] = offset + count
+	return offset
+}
+
+func (t *AlphaRepository) ResetCursor(name string) {
+	delete(t.cursors, name)
+}
+
 func (t *AlphaRepository) ReleaseHeldRelation(name string) {
 	delete(t.heldRelations, name)
+	delete(t.heldSources, name)
+	delete(t.heldTuples, name)
 }
 
 func (t *AlphaRepository) ClearAll() {
@@ -122,5 +181,8 @@ func (t *AlphaRepository) ClearAll() {
 	clear(t.relations)
 	clear(t.calculatedRelations)
 	clear(t.heldRelations)
+	clear(t.heldSources)
+	clear(t.heldTuples)
 	clear(t.getRelations)
+	clear(t.cursors)
 }
@@ -38,6 +38,11 @@ func (r *Router) Server() {
 
 	router.Post("/alpha/execute", r.alphaController.TestingServer)
 	router.Post("/alpha/validate", r.alphaController.ValidationServer)
+	router.Post("/alpha/fmt", r.alphaController.FormatServer)
+	router.Post("/alpha/tokens", r.alphaController.TokensServer)
+	router.Post("/alpha/ast", r.alphaController.ASTServer)
+	router.Post("/alpha/algebra", r.alphaController.AlgebraServer)
+	router.Post("/alpha/sql", r.alphaController.SQLServer)
 
 	port := ":8080"
 	err := http.ListenAndServe(port, router)
@@ -48,6 +53,20 @@ func (r *Router) Server() {
 }
 
 func (r *Router) Cli() {
+	if path := flag.Lookup("fmt").Value.String(); path != "" {
+		if err := r.alphaController.FormatCli(path); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if path := flag.Lookup("sql").Value.String(); path != "" {
+		if err := r.alphaController.SQLCli(path); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	fmt.Println("cli app launched")
 
 	var err error
@@ -9,7 +9,6 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"github.com/kr/pretty"
 	"io"
 	"os"
 	"strings"
@@ -34,18 +33,88 @@ func (e *AlphaService) Execute(body io.ReadCloser) (model.TestingSender, error)
 	e.alphaRepository.ClearAll()
 	e.alphaRepository.AddRelations(receiver.Relations)
 
+	switch receiver.Language {
+	case "", "alpha":
+		return e.executeAlpha(receiver)
+	case "algebra":
+		return e.executeAlgebra(receiver)
+	case "sql":
+		return e.executeSQL(receiver)
+	default:
+		return model.TestingSender{}, &entity.CustomError{
+			ErrorType: entity.ResponseTypes["CF"],
+			Message:   fmt.Sprintf("unknown language %s", receiver.Language),
+		}
+	}
+}
+
+func (e *AlphaService) executeAlpha(receiver model.TestingReceiver) (model.TestingSender, error) {
 	reader := strings.NewReader(receiver.Query)
-	program := operation.GenerateAST(bufio.NewReader(reader))
-	if _, err := pretty.Print(program); err != nil {
+	program, errors := operation.GenerateAST(bufio.NewReader(reader))
+	if len(errors) > 0 {
+		return model.TestingSender{}, entity.CustomErrors(errors)
+	}
+
+	schemas, err := entity.NewSchemas(receiver.Relations, receiver.Schemas)
+	if err != nil {
 		return model.TestingSender{}, err
 	}
 
-	interpreter := operation.NewInterpreter(e.alphaRepository)
-	err := interpreter.Evaluate(&program)
+	return e.evaluate(program, schemas)
+}
+
+// executeSQL compiles the SQL queries into an ALPHA program, evaluated as
+// the programs of executeAlpha.
+func (e *AlphaService) executeSQL(receiver model.TestingReceiver) (model.TestingSender, error) {
+	schemas, err := entity.NewSchemas(receiver.Relations, receiver.Schemas)
 	if err != nil {
 		return model.TestingSender{}, err
 	}
 
+	program, errors := operation.GenerateSQLProgram(bufio.NewReader(strings.NewReader(receiver.Query)), schemas)
+	if len(errors) > 0 {
+		return model.TestingSender{}, entity.CustomErrors(errors)
+	}
+
+	return e.evaluate(program, schemas)
+}
+
+func (e *AlphaService) evaluate(program operation.Program, schemas entity.Schemas) (model.TestingSender, error) {
+	binder := operation.NewBinder(schemas)
+	errors := binder.Bind(&program)
+	if len(errors) > 0 {
+		return model.TestingSender{}, entity.CustomErrors(errors)
+	}
+
+	typeChecker := operation.NewTypeChecker(schemas)
+	if errors = typeChecker.Check(&program); len(errors) > 0 {
+		return model.TestingSender{}, entity.CustomErrors(errors)
+	}
+
+	interpreter := operation.NewInterpreter(e.alphaRepository)
+	if err := interpreter.Evaluate(&program); err != nil {
+		return model.TestingSender{}, err
+	}
+
+	output := e.alphaRepository.GetGetRelations()
+	return model.TestingSender{
+		Results: &output,
+	}, nil
+}
+
+func (e *AlphaService) executeAlgebra(receiver model.TestingReceiver) (model.TestingSender, error) {
+	statements, errors := operation.GenerateAlgebra(bufio.NewReader(strings.NewReader(receiver.Query)))
+	if len(errors) > 0 {
+		return model.TestingSender{}, entity.CustomErrors(errors)
+	}
+
+	executor := operation.NewAlgebraExecutor(e.alphaRepository)
+	for _, statement := range statements {
+		if err := executor.Execute(statement); err != nil {
+			return model.TestingSender{}, err
+		}
+	}
+
 	output := e.alphaRepository.GetGetRelations()
 	return model.TestingSender{
 		Results: &output,
@@ -107,7 +176,7 @@ func (e *AlphaService) ValidationCommon(validationReceiver model.ValidationRecei
 			return err
 		}
 
-		var result entity.Relations
+		var result entity.Workspaces
 		err = json.NewDecoder(resultFile).Decode(&result)
 		if err != nil {
 			return &entity.CustomError{
@@ -120,6 +189,7 @@ func (e *AlphaService) ValidationCommon(validationReceiver model.ValidationRecei
 		if err = json.NewEncoder(&testData).Encode(model.TestingReceiver{
 			Query:     validationReceiver.Query,
 			Relations: relations,
+			Language:  validationReceiver.Language,
 		}); err != nil {
 			return &entity.CustomError{
 				ErrorType: entity.ResponseTypes["CF"],
@@ -143,7 +213,7 @@ func (e *AlphaService) ValidationCommon(validationReceiver model.ValidationRecei
 			return err
 		}
 
-		if !(&result).RelationsEqual(processingResult.Results) {
+		if !(&result).WorkspacesEqual(processingResult.Results) {
 			return &entity.CustomError{
 				ErrorType: entity.ResponseTypes["WA"],
 				Message:   fmt.Sprintf("Test %d has failed", testNum+1),
@@ -190,3 +260,179 @@ func (e *AlphaService) ValidationCli() error {
 
 	return nil
 }
+
+func (e *AlphaService) Format(body io.ReadCloser) (model.FormattingSender, error) {
+	var receiver model.SourceReceiver
+	if err := json.NewDecoder(body).Decode(&receiver); err != nil {
+		return model.FormattingSender{}, err
+	}
+
+	query, err := e.format(strings.NewReader(receiver.Query))
+	if err != nil {
+		return model.FormattingSender{}, err
+	}
+
+	return model.FormattingSender{Query: query}, nil
+}
+
+func (e *AlphaService) FormatCli(path string) error {
+	source, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	query, err := e.format(source)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(query)
+	return nil
+}
+
+func (e *AlphaService) format(source io.Reader) (string, error) {
+	program, errors := operation.GenerateAST(bufio.NewReader(source))
+	if len(errors) > 0 {
+		return "", entity.CustomErrors(errors)
+	}
+
+	printer := operation.NewPrinter()
+	return printer.Print(&program), nil
+}
+
+func (e *AlphaService) Tokens(body io.ReadCloser) (model.TokensSender, error) {
+	var receiver model.SourceReceiver
+	if err := json.NewDecoder(body).Decode(&receiver); err != nil {
+		return model.TokensSender{}, err
+	}
+
+	lexer := model.NewLexer(bufio.NewReader(strings.NewReader(receiver.Query)))
+	statements := lexer.Lex()
+	return model.TokensSender{
+		Statements: statements,
+		Errors:     lexer.Errors(),
+	}, nil
+}
+
+func (e *AlphaService) AST(body io.ReadCloser) (model.ASTSender, error) {
+	var receiver model.SourceReceiver
+	if err := json.NewDecoder(body).Decode(&receiver); err != nil {
+		return model.ASTSender{}, err
+	}
+
+	program, errors := operation.GenerateAST(bufio.NewReader(strings.NewReader(receiver.Query)))
+	return model.ASTSender{
+		Program: &program,
+		Errors:  errors,
+	}, nil
+}
+
+func (e *AlphaService) Algebra(body io.ReadCloser) (model.AlgebraSender, error) {
+	var receiver model.TestingReceiver
+	if err := json.NewDecoder(body).Decode(&receiver); err != nil {
+		return model.AlgebraSender{}, err
+	}
+
+	e.alphaRepository.ClearAll()
+	e.alphaRepository.AddRelations(receiver.Relations)
+
+	program, errors := operation.GenerateAST(bufio.NewReader(strings.NewReader(receiver.Query)))
+	if len(errors) > 0 {
+		return model.AlgebraSender{}, entity.CustomErrors(errors)
+	}
+
+	schemas, err := entity.NewSchemas(receiver.Relations, receiver.Schemas)
+	if err != nil {
+		return model.AlgebraSender{}, err
+	}
+
+	binder := operation.NewBinder(schemas)
+	if errors = binder.Bind(&program); len(errors) > 0 {
+		return model.AlgebraSender{}, entity.CustomErrors(errors)
+	}
+
+	reducer := operation.NewReducer()
+	statements, err := reducer.Reduce(&program)
+	if err != nil {
+		return model.AlgebraSender{}, err
+	}
+
+	executor := operation.NewAlgebraExecutor(e.alphaRepository)
+	expressions := make([]string, 0, len(statements))
+	for _, statement := range statements {
+		if err = executor.Execute(statement); err != nil {
+			return model.AlgebraSender{}, err
+		}
+
+		if statement.GetKind() == model.GET.String() {
+			expressions = append(expressions, statement.String())
+		}
+	}
+
+	output := e.alphaRepository.GetGetRelations()
+	return model.AlgebraSender{
+		Expressions: expressions,
+		Results:     &output,
+	}, nil
+}
+
+func (e *AlphaService) SQL(body io.ReadCloser) (model.SQLSender, error) {
+	sql, err := e.translate(body)
+	if err != nil {
+		return model.SQLSender{}, err
+	}
+
+	return model.SQLSender{SQL: sql}, nil
+}
+
+func (e *AlphaService) SQLCli(path string) error {
+	source, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	sql, err := e.translate(source)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(sql)
+	return nil
+}
+
+func (e *AlphaService) translate(source io.Reader) (string, error) {
+	var receiver model.TestingReceiver
+	if err := json.NewDecoder(source).Decode(&receiver); err != nil {
+		return "", err
+	}
+
+	program, errors := operation.GenerateAST(bufio.NewReader(strings.NewReader(receiver.Query)))
+	if len(errors) > 0 {
+		return "", entity.CustomErrors(errors)
+	}
+
+	schemas, err := entity.NewSchemas(receiver.Relations, receiver.Schemas)
+	if err != nil {
+		return "", err
+	}
+
+	binder := operation.NewBinder(schemas)
+	if errors = binder.Bind(&program); len(errors) > 0 {
+		return "", entity.CustomErrors(errors)
+	}
+
+	translator := operation.NewSQLTranslator(schemas)
+	data, err := translator.TranslateData(receiver.Relations, schemas)
+	if err != nil {
+		return "", err
+	}
+
+	queries, err := translator.Translate(&program)
+	if err != nil {
+		return "", err
+	}
+
+	return data + queries, nil
+}